@@ -6,5 +6,7 @@ require (
 	github.com/forteilgmbh/segment-config-go v0.2.1-0.20230105103044-a8c2cf134175
 	github.com/google/go-cmp v0.5.7
 	github.com/hashicorp/go-multierror v1.1.1
+	github.com/hashicorp/go-retryablehttp v0.7.1-0.20211018174820-ff6d014e72d9
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.12.0
+	golang.org/x/time v0.3.0
 )
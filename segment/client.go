@@ -0,0 +1,236 @@
+package segment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/forteilgmbh/segment-config-go/segment"
+	"github.com/hashicorp/go-retryablehttp"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	apiVersion = "v1beta"
+	mediaType  = "application/json"
+)
+
+// Regions maps the supported Segment regions to the base URLs of their Config API.
+var Regions = map[string]string{
+	"eu": "https://eu1.api.segmentapis.com",
+	"us": "https://platform.segmentapis.com",
+}
+
+const DefaultRegion = "eu"
+
+const (
+	DefaultMaxRetries   = 4
+	DefaultRetryMaxWait = 30 * time.Second
+	retryMinWait        = 1 * time.Second
+)
+
+// ClientConfig holds the settings used to build a Client.
+type ClientConfig struct {
+	AccessToken string
+	Workspace   string
+	// BaseURL of the Segment Config API, without the API version (e.g. "https://eu1.api.segmentapis.com")
+	BaseURL string
+	// MaxRetries is the number of times a request is retried after a rate limit or a transient server error
+	MaxRetries int
+	// RetryMaxWait caps the wait between two attempts, including waits requested through Retry-After
+	RetryMaxWait time.Duration
+	// RequestsPerSecond limits the rate of requests sent to the API; 0 means unlimited
+	RequestsPerSecond float64
+	// MaxConcurrentRequests limits the number of requests in flight; 0 means unlimited
+	MaxConcurrentRequests int
+}
+
+// Client manages communication with Segment Config API.
+//
+// It mirrors the client of segment-config-go, but talks to a configurable base URL.
+type Client struct {
+	baseURL     string
+	apiVersion  string
+	accessToken string
+	Workspace   string
+	client      *retryablehttp.Client
+	catalog     catalogCache
+}
+
+// NewClient creates a new Segment Config API client.
+func NewClient(config ClientConfig) *Client {
+	c := retryablehttp.NewClient()
+	c.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	c.CheckRetry = retryPolicy
+	c.Backoff = retryBackoff
+	c.RetryMax = config.MaxRetries
+	c.RetryWaitMin = retryMinWait
+	c.RetryWaitMax = config.RetryMaxWait
+	if c.RetryWaitMax < c.RetryWaitMin {
+		c.RetryWaitMin = c.RetryWaitMax
+	}
+	c.HTTPClient.Transport = newLimitedTransport(c.HTTPClient.Transport, config.RequestsPerSecond, config.MaxConcurrentRequests)
+
+	return &Client{
+		baseURL:     strings.TrimRight(config.BaseURL, "/"),
+		apiVersion:  apiVersion,
+		accessToken: config.AccessToken,
+		Workspace:   config.Workspace,
+		client:      c,
+	}
+}
+
+// BaseURL returns the base URL the client sends its requests to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, data interface{}) ([]byte, error) {
+	b := bytes.NewBuffer(nil)
+	if data != nil {
+		if err := json.NewEncoder(b).Encode(data); err != nil {
+			return nil, fmt.Errorf("json encoding data for %s request failed: %w", method, err)
+		}
+	}
+
+	uri := fmt.Sprintf("%s/%s/%s", c.baseURL, c.apiVersion, strings.Trim(endpoint, "/"))
+	req, err := retryablehttp.NewRequestWithContext(context.WithValue(ctx, requestMethodKey{}, method), method, uri, b)
+	if err != nil {
+		return nil, fmt.Errorf("creating %s request to %s failed: %w", method, uri, err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.accessToken))
+	req.Header.Set("Content-Type", mediaType)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("performing %s request to %s failed: %w", method, uri, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusCreated:
+	case http.StatusUnauthorized:
+		return nil, &segment.SegmentApiError{Message: "invalid access token", Code: resp.StatusCode}
+	case http.StatusForbidden:
+		return nil, &segment.SegmentApiError{Message: "unauthorized access to endpoint", Code: resp.StatusCode}
+	case http.StatusNotFound:
+		return nil, &segment.SegmentApiError{Message: "the requested uri does not exist", Code: resp.StatusCode}
+	case http.StatusBadRequest, http.StatusInternalServerError:
+		return nil, handleErrorResponse(resp.Body)
+	case http.StatusTooManyRequests:
+		return nil, &segment.SegmentApiError{Message: "too many requests to API", Code: resp.StatusCode}
+	default:
+		return nil, &segment.SegmentApiError{Message: "bad response code", Code: resp.StatusCode}
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response from %s request to %s failed: %w", method, uri, err)
+	}
+
+	return body, nil
+}
+
+func handleErrorResponse(body io.Reader) error {
+	errBody, err := ioutil.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("the request error body is invalid: %s", errBody)
+	}
+
+	var apiErr segment.SegmentApiError
+	if err := json.Unmarshal(errBody, &apiErr); err != nil {
+		return fmt.Errorf("request error unknown: %s", errBody)
+	}
+
+	return &apiErr
+}
+
+// requestMethodKey is the context key of the method of a request, as the retry policy does not get the request itself
+type requestMethodKey struct{}
+
+// retryPolicy retries connection errors, rate limits and transient server errors.
+// 500s which the Segment API is known to return for missing resources are not retried, see isPermanentApiError.
+// POST requests create objects, so they are only retried when Segment is known not to have processed them,
+// as a retry would otherwise create a duplicate or fail with a conflict.
+func retryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+	if method, _ := ctx.Value(requestMethodKey{}).(string); method == http.MethodPost {
+		return retryPostPolicy(resp, err), nil
+	}
+	if err != nil || resp == nil {
+		return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true, nil
+	case http.StatusInternalServerError:
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return true, nil
+		}
+		var apiErr segment.SegmentApiError
+		if json.Unmarshal(body, &apiErr) == nil && isPermanentApiError(&apiErr) {
+			return false, nil
+		}
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// retryPostPolicy retries rate limited requests and requests rejected as unavailable with a Retry-After header only
+func retryPostPolicy(resp *http.Response, err error) bool {
+	if err != nil || resp == nil {
+		return false
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusServiceUnavailable:
+		return resp.Header.Get("Retry-After") != ""
+	default:
+		return false
+	}
+}
+
+// retryBackoff waits as long as requested by the Retry-After header of a rate limited or unavailable response,
+// and backs off exponentially otherwise. The wait never exceeds max.
+func retryBackoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > max {
+				return max
+			}
+			return wait
+		}
+	}
+	return retryablehttp.DefaultBackoff(min, max, attemptNum, nil)
+}
+
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
@@ -0,0 +1,138 @@
+package segment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+const (
+	catalogEndpoint    = "catalog"
+	catalogPageSize    = 100
+	catalogSourcesPath = catalogEndpoint + "/sources"
+
+	catalogDestinationsPath = catalogEndpoint + "/destinations"
+)
+
+// CatalogSource is an entry of the Segment source catalog
+type CatalogSource struct {
+	Name        string   `json:"name"`
+	DisplayName string   `json:"display_name"`
+	Description string   `json:"description"`
+	Categories  []string `json:"categories"`
+	// Settings are the options accepted in the settings of the source
+	Settings []CatalogOption `json:"settings"`
+}
+
+// CatalogDestination is an entry of the Segment destination catalog
+type CatalogDestination struct {
+	Name            string   `json:"name"`
+	DisplayName     string   `json:"display_name"`
+	Description     string   `json:"description"`
+	Categories      []string `json:"categories"`
+	ConnectionModes []string `json:"connection_modes"`
+	// Settings are the options accepted in the configs of the destination,
+	// each one being set in a config named "<destination-name>/config/<option-name>"
+	Settings []CatalogOption `json:"settings"`
+}
+
+// CatalogOption describes a setting of a catalog entry
+type CatalogOption struct {
+	Name        string      `json:"name"`
+	DisplayName string      `json:"display_name"`
+	Description string      `json:"description"`
+	Type        string      `json:"type"`
+	Required    bool        `json:"required"`
+	Default     interface{} `json:"default"`
+}
+
+type catalogSourcesListResponse struct {
+	Sources       []CatalogSource `json:"sources"`
+	NextPageToken string          `json:"next_page_token"`
+}
+
+type catalogDestinationsListResponse struct {
+	Destinations  []CatalogDestination `json:"destinations"`
+	NextPageToken string               `json:"next_page_token"`
+}
+
+// catalogCache keeps the catalog for the lifetime of the client, i.e. a single Terraform run,
+// as it is the same for all resources and rarely changes
+type catalogCache struct {
+	mu           sync.Mutex
+	sources      []CatalogSource
+	destinations []CatalogDestination
+}
+
+// ListCatalogSources returns all the entries of the source catalog. The catalog is fetched only once per client.
+func (c *Client) ListCatalogSources(ctx context.Context) ([]CatalogSource, error) {
+	c.catalog.mu.Lock()
+	defer c.catalog.mu.Unlock()
+
+	if c.catalog.sources != nil {
+		return c.catalog.sources, nil
+	}
+
+	sources := make([]CatalogSource, 0)
+	pageToken := ""
+	for {
+		var page catalogSourcesListResponse
+		if err := c.getCatalogPage(ctx, catalogSourcesPath, pageToken, &page); err != nil {
+			return nil, err
+		}
+		sources = append(sources, page.Sources...)
+		if page.NextPageToken == "" {
+			break
+		}
+		pageToken = page.NextPageToken
+	}
+
+	c.catalog.sources = sources
+	return sources, nil
+}
+
+// ListCatalogDestinations returns all the entries of the destination catalog. The catalog is fetched only once per client.
+func (c *Client) ListCatalogDestinations(ctx context.Context) ([]CatalogDestination, error) {
+	c.catalog.mu.Lock()
+	defer c.catalog.mu.Unlock()
+
+	if c.catalog.destinations != nil {
+		return c.catalog.destinations, nil
+	}
+
+	destinations := make([]CatalogDestination, 0)
+	pageToken := ""
+	for {
+		var page catalogDestinationsListResponse
+		if err := c.getCatalogPage(ctx, catalogDestinationsPath, pageToken, &page); err != nil {
+			return nil, err
+		}
+		destinations = append(destinations, page.Destinations...)
+		if page.NextPageToken == "" {
+			break
+		}
+		pageToken = page.NextPageToken
+	}
+
+	c.catalog.destinations = destinations
+	return destinations, nil
+}
+
+func (c *Client) getCatalogPage(ctx context.Context, path, pageToken string, page interface{}) error {
+	query := url.Values{}
+	query.Set("page_size", fmt.Sprintf("%d", catalogPageSize))
+	if pageToken != "" {
+		query.Set("page_token", pageToken)
+	}
+	data, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s?%s", path, query.Encode()), nil)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, page); err != nil {
+		return fmt.Errorf("failed to unmarshal catalog response: %w", err)
+	}
+	return nil
+}
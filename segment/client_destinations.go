@@ -0,0 +1,180 @@
+package segment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/forteilgmbh/segment-config-go/segment"
+	"net/http"
+)
+
+const (
+	destinationsEndpoint       = "destinations"
+	destinationFiltersEndpoint = "filters"
+)
+
+type destinationCreateRequest struct {
+	Destination segment.Destination `json:"destination,omitempty"`
+}
+
+type destinationUpdateRequest struct {
+	Destination segment.Destination `json:"destination,omitempty"`
+	UpdateMask  segment.UpdateMask  `json:"update_mask,omitempty"`
+}
+
+type destinationFiltersListResponse struct {
+	Filters []segment.DestinationFilter `json:"filters"`
+}
+
+type destinationFilterCRURequest struct {
+	Filter     segment.DestinationFilter `json:"filter"`
+	UpdateMask segment.UpdateMask        `json:"update_mask"`
+}
+
+var destinationUpdateMask = segment.UpdateMask{Paths: []string{"destination.config", "destination.enabled"}}
+
+var destinationFilterUpdateMask = segment.UpdateMask{Paths: []string{"if", "actions", "title", "description", "enabled"}}
+
+func (c *Client) destinationsPath(srcSlug string) string {
+	return fmt.Sprintf("%s/%s", c.sourcePath(srcSlug), destinationsEndpoint)
+}
+
+func (c *Client) destinationPath(srcSlug, dstSlug string) string {
+	return fmt.Sprintf("%s/%s", c.destinationsPath(srcSlug), dstSlug)
+}
+
+func (c *Client) destinationFiltersPath(srcSlug, dstSlug string) string {
+	return fmt.Sprintf("%s/%s", c.destinationPath(srcSlug, dstSlug), destinationFiltersEndpoint)
+}
+
+// ListDestinations returns all destinations of a source
+func (c *Client) ListDestinations(ctx context.Context, srcSlug string) (segment.Destinations, error) {
+	var d segment.Destinations
+	data, err := c.doRequest(ctx, http.MethodGet, c.destinationsPath(srcSlug), nil)
+	if err != nil {
+		return d, err
+	}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return d, fmt.Errorf("failed to unmarshal destinations response: %w", err)
+	}
+	return d, nil
+}
+
+// GetDestination returns information about a destination of a source
+func (c *Client) GetDestination(ctx context.Context, srcSlug string, dstSlug string) (segment.Destination, error) {
+	var d segment.Destination
+	data, err := c.doRequest(ctx, http.MethodGet, c.destinationPath(srcSlug, dstSlug), nil)
+	if err != nil {
+		return d, err
+	}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return d, fmt.Errorf("failed to unmarshal destination response: %w", err)
+	}
+	return d, nil
+}
+
+// CreateDestination creates a new destination for a source
+func (c *Client) CreateDestination(ctx context.Context, srcSlug string, dstSlug string, connMode string, enabled bool, configs []segment.DestinationConfig) (segment.Destination, error) {
+	var d segment.Destination
+	req := destinationCreateRequest{segment.Destination{
+		Name:           c.destinationPath(srcSlug, dstSlug),
+		ConnectionMode: connMode,
+		Enabled:        enabled,
+		Configs:        configs,
+	}}
+	data, err := c.doRequest(ctx, http.MethodPost, c.destinationsPath(srcSlug), req)
+	if err != nil {
+		return d, err
+	}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return d, fmt.Errorf("failed to unmarshal destination response: %w", err)
+	}
+	return d, nil
+}
+
+// UpdateDestination updates an existing destination with a new config
+func (c *Client) UpdateDestination(ctx context.Context, srcSlug string, dstSlug string, enabled bool, configs []segment.DestinationConfig) (segment.Destination, error) {
+	var d segment.Destination
+	req := destinationUpdateRequest{
+		Destination: segment.Destination{
+			Name:    c.destinationPath(srcSlug, dstSlug),
+			Enabled: enabled,
+			Configs: configs,
+		},
+		UpdateMask: destinationUpdateMask,
+	}
+	data, err := c.doRequest(ctx, http.MethodPatch, c.destinationPath(srcSlug, dstSlug), req)
+	if err != nil {
+		return d, err
+	}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return d, fmt.Errorf("failed to unmarshal destination response: %w", err)
+	}
+	return d, nil
+}
+
+// DeleteDestination deletes a destination of a source
+func (c *Client) DeleteDestination(ctx context.Context, srcSlug string, dstSlug string) error {
+	_, err := c.doRequest(ctx, http.MethodDelete, c.destinationPath(srcSlug, dstSlug), nil)
+	return err
+}
+
+// ListDestinationFilters returns all filters of a destination
+func (c *Client) ListDestinationFilters(ctx context.Context, srcSlug string, dstSlug string) ([]segment.DestinationFilter, error) {
+	var d destinationFiltersListResponse
+	data, err := c.doRequest(ctx, http.MethodGet, c.destinationFiltersPath(srcSlug, dstSlug), nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal destination filters response: %w", err)
+	}
+	return d.Filters, nil
+}
+
+// GetDestinationFilter returns information about a destination filter
+func (c *Client) GetDestinationFilter(ctx context.Context, srcSlug string, dstSlug string, filterId string) (*segment.DestinationFilter, error) {
+	data, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%s", c.destinationFiltersPath(srcSlug, dstSlug), filterId), nil)
+	if err != nil {
+		return nil, err
+	}
+	var filter segment.DestinationFilter
+	if err := json.Unmarshal(data, &filter); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal destination filter response: %w", err)
+	}
+	return &filter, nil
+}
+
+// CreateDestinationFilter creates a new filter for a destination
+func (c *Client) CreateDestinationFilter(ctx context.Context, srcSlug string, dstSlug string, filter segment.DestinationFilter) (*segment.DestinationFilter, error) {
+	req := destinationFilterCRURequest{Filter: filter, UpdateMask: destinationFilterUpdateMask}
+	data, err := c.doRequest(ctx, http.MethodPost, c.destinationFiltersPath(srcSlug, dstSlug), req)
+	if err != nil {
+		return nil, err
+	}
+	var result segment.DestinationFilter
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal destination filter response: %w", err)
+	}
+	return &result, nil
+}
+
+// UpdateDestinationFilter updates an existing destination filter, identified by filter.Name
+func (c *Client) UpdateDestinationFilter(ctx context.Context, srcSlug string, dstSlug string, filter segment.DestinationFilter) (*segment.DestinationFilter, error) {
+	req := destinationFilterCRURequest{Filter: filter, UpdateMask: destinationFilterUpdateMask}
+	data, err := c.doRequest(ctx, http.MethodPatch, filter.Name, req)
+	if err != nil {
+		return nil, err
+	}
+	var result segment.DestinationFilter
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal destination filter response: %w", err)
+	}
+	return &result, nil
+}
+
+// DeleteDestinationFilter deletes a destination filter
+func (c *Client) DeleteDestinationFilter(ctx context.Context, srcSlug string, dstSlug string, filterId string) error {
+	_, err := c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%s", c.destinationFiltersPath(srcSlug, dstSlug), filterId), nil)
+	return err
+}
@@ -0,0 +1,82 @@
+package segment
+
+import (
+	"golang.org/x/time/rate"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+)
+
+// limitedTransport throttles the requests sent through it, so that a single apply cannot exhaust
+// the API quota of the whole workspace. Every attempt counts, including retries.
+type limitedTransport struct {
+	base http.RoundTripper
+	// limiter caps the number of requests per second; nil means unlimited
+	limiter *rate.Limiter
+	// slots caps the number of requests in flight; nil means unlimited
+	slots chan struct{}
+}
+
+func newLimitedTransport(base http.RoundTripper, requestsPerSecond float64, maxConcurrentRequests int) http.RoundTripper {
+	if requestsPerSecond <= 0 && maxConcurrentRequests <= 0 {
+		return base
+	}
+	t := &limitedTransport{base: base}
+	if requestsPerSecond > 0 {
+		t.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), int(math.Max(1, math.Ceil(requestsPerSecond))))
+	}
+	if maxConcurrentRequests > 0 {
+		t.slots = make(chan struct{}, maxConcurrentRequests)
+	}
+	return t
+}
+
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := t.release()
+
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	// the slot is held until the response body is consumed
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+func (t *limitedTransport) release() func() {
+	if t.slots == nil {
+		return func() {}
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-t.slots })
+	}
+}
+
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
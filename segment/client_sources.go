@@ -0,0 +1,170 @@
+package segment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/forteilgmbh/segment-config-go/segment"
+	"net/http"
+)
+
+const (
+	workspacesEndpoint = "workspaces"
+	sourcesEndpoint    = "sources"
+)
+
+// Source extends the source of the client library with the properties which it does not support
+type Source struct {
+	segment.Source
+	DisplayName string `json:"display_name,omitempty"`
+	CatalogId   string `json:"catalog_id,omitempty"`
+	// Enabled is nil if the API does not return it, so that it can be told apart from a disabled source
+	Enabled  *bool                  `json:"enabled,omitempty"`
+	Labels   map[string]string      `json:"labels,omitempty"`
+	Settings map[string]interface{} `json:"settings,omitempty"`
+}
+
+// Sources is a list of sources
+type Sources struct {
+	Sources []Source `json:"sources,omitempty"`
+}
+
+type sourceCreateRequest struct {
+	Source segment.Source `json:"source,omitempty"`
+}
+
+type sourceUpdateRequest struct {
+	Source     Source             `json:"source,omitempty"`
+	UpdateMask segment.UpdateMask `json:"update_mask,omitempty"`
+}
+
+type sourceConfigUpdateRequest struct {
+	Config     segment.SourceConfig `json:"schema_config,omitempty"`
+	UpdateMask segment.UpdateMask   `json:"update_mask,omitempty"`
+}
+
+var sourceConfigUpdateMask = segment.UpdateMask{Paths: []string{
+	"schema_config.allow_unplanned_track_events",
+	"schema_config.allow_unplanned_identify_traits",
+	"schema_config.allow_unplanned_group_traits",
+	"schema_config.forwarding_blocked_events_to",
+	"schema_config.allow_unplanned_track_event_properties",
+	"schema_config.allow_track_event_on_violations",
+	"schema_config.allow_identify_traits_on_violations",
+	"schema_config.allow_group_traits_on_violations",
+	"schema_config.forwarding_violations_to",
+	"schema_config.allow_track_properties_on_violations",
+	"schema_config.common_track_event_on_violations",
+	"schema_config.common_identify_event_on_violations",
+	"schema_config.common_group_event_on_violations",
+}}
+
+func (c *Client) sourcesPath() string {
+	return fmt.Sprintf("%s/%s/%s", workspacesEndpoint, c.Workspace, sourcesEndpoint)
+}
+
+func (c *Client) sourcePath(srcSlug string) string {
+	return fmt.Sprintf("%s/%s", c.sourcesPath(), srcSlug)
+}
+
+// ListSources returns all sources of the workspace
+func (c *Client) ListSources(ctx context.Context) (Sources, error) {
+	var s Sources
+	data, err := c.doRequest(ctx, http.MethodGet, c.sourcesPath(), nil)
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("failed to unmarshal sources response: %w", err)
+	}
+	return s, nil
+}
+
+// GetSource returns information about a source
+func (c *Client) GetSource(ctx context.Context, srcSlug string) (Source, error) {
+	var s Source
+	data, err := c.doRequest(ctx, http.MethodGet, c.sourcePath(srcSlug), nil)
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("failed to unmarshal source response: %w", err)
+	}
+	return s, nil
+}
+
+// CreateSource creates a new source
+func (c *Client) CreateSource(ctx context.Context, srcSlug string, catName string) (Source, error) {
+	var s Source
+	req := sourceCreateRequest{segment.Source{
+		Name:        c.sourcePath(srcSlug),
+		CatalogName: catName,
+	}}
+	data, err := c.doRequest(ctx, http.MethodPost, c.sourcesPath(), req)
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("failed to unmarshal source response: %w", err)
+	}
+	return s, nil
+}
+
+// UpdateSource updates the properties of a source listed in paths,
+// e.g. "display_name" or "enabled"
+func (c *Client) UpdateSource(ctx context.Context, srcSlug string, source Source, paths []string) (Source, error) {
+	var s Source
+	source.Name = c.sourcePath(srcSlug)
+	mask := segment.UpdateMask{Paths: make([]string, 0, len(paths))}
+	for _, p := range paths {
+		mask.Paths = append(mask.Paths, fmt.Sprintf("source.%s", p))
+	}
+	req := sourceUpdateRequest{
+		Source:     source,
+		UpdateMask: mask,
+	}
+	data, err := c.doRequest(ctx, http.MethodPatch, c.sourcePath(srcSlug), req)
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("failed to unmarshal source response: %w", err)
+	}
+	return s, nil
+}
+
+// DeleteSource deletes a source from the workspace
+func (c *Client) DeleteSource(ctx context.Context, srcSlug string) error {
+	_, err := c.doRequest(ctx, http.MethodDelete, c.sourcePath(srcSlug), nil)
+	return err
+}
+
+// GetSourceConfig retrieves the schema config of a given source
+func (c *Client) GetSourceConfig(ctx context.Context, srcSlug string) (segment.SourceConfig, error) {
+	var result segment.SourceConfig
+	data, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s/schema-config", c.sourcePath(srcSlug)), nil)
+	if err != nil {
+		return result, err
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to unmarshal source schema config response: %w", err)
+	}
+	return result, nil
+}
+
+// UpdateSourceConfig updates the schema config of a given source
+func (c *Client) UpdateSourceConfig(ctx context.Context, srcSlug string, config segment.SourceConfig) (segment.SourceConfig, error) {
+	var result segment.SourceConfig
+	req := sourceConfigUpdateRequest{
+		Config:     config,
+		UpdateMask: sourceConfigUpdateMask,
+	}
+	data, err := c.doRequest(ctx, http.MethodPatch, fmt.Sprintf("%s/schema-config", c.sourcePath(srcSlug)), req)
+	if err != nil {
+		return result, err
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to unmarshal source schema config response: %w", err)
+	}
+	return result, nil
+}
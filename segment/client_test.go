@@ -0,0 +1,367 @@
+package segment_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/forteilgmbh/terraform-provider-segment/segment"
+	"github.com/google/go-cmp/cmp"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_endpoint(t *testing.T) {
+	var gotPath, gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		fmt.Fprint(w, `{"name": "workspaces/myworkspace/sources/ios", "catalog_name": "catalog/sources/ios"}`)
+	}))
+	defer server.Close()
+
+	client := segment.NewClient(segment.ClientConfig{
+		AccessToken: "token",
+		Workspace:   "myworkspace",
+		BaseURL:     server.URL + "/",
+	})
+
+	src, err := client.GetSource(context.Background(), "ios")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if gotPath != "/v1beta/workspaces/myworkspace/sources/ios" {
+		t.Errorf("invalid request path: %q", gotPath)
+	}
+	if gotAuth != "Bearer token" {
+		t.Errorf("invalid Authorization header: %q", gotAuth)
+	}
+	if src.CatalogName != "catalog/sources/ios" {
+		t.Errorf("invalid catalog name: %q", src.CatalogName)
+	}
+}
+
+func TestClient_notFound(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	client := segment.NewClient(segment.ClientConfig{Workspace: "myworkspace", BaseURL: server.URL})
+
+	_, err := client.GetSource(context.Background(), "ios")
+	if !segment.IsNotFoundErr(err) {
+		t.Fatalf("expected not found error, got: %v", err)
+	}
+}
+
+func TestClient_retryRateLimited(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"name": "workspaces/myworkspace/sources/ios"}`)
+	}))
+	defer server.Close()
+
+	client := segment.NewClient(segment.ClientConfig{
+		Workspace:    "myworkspace",
+		BaseURL:      server.URL,
+		MaxRetries:   3,
+		RetryMaxWait: time.Second,
+	})
+
+	if _, err := client.GetSource(context.Background(), "ios"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if attempts != 3 {
+		t.Errorf("invalid number of attempts: expected: %d, actual: %d", 3, attempts)
+	}
+}
+
+func TestClient_retryExhausted(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := segment.NewClient(segment.ClientConfig{
+		Workspace:    "myworkspace",
+		BaseURL:      server.URL,
+		MaxRetries:   2,
+		RetryMaxWait: time.Millisecond,
+	})
+
+	if _, err := client.GetSource(context.Background(), "ios"); err == nil {
+		t.Fatal("expected error")
+	}
+	if attempts != 3 {
+		t.Errorf("invalid number of attempts: expected: %d, actual: %d", 3, attempts)
+	}
+}
+
+func TestClient_retryPost(t *testing.T) {
+	cases := []struct {
+		status     int
+		retryAfter string
+		attempts   int
+	}{
+		{http.StatusBadGateway, "", 1},
+		{http.StatusGatewayTimeout, "", 1},
+		{http.StatusInternalServerError, "", 1},
+		{http.StatusServiceUnavailable, "", 1},
+		{http.StatusServiceUnavailable, "0", 3},
+		{http.StatusTooManyRequests, "0", 3},
+	}
+
+	for _, c := range cases {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if r.Method != http.MethodPost {
+				t.Errorf("invalid method: %q", r.Method)
+			}
+			if c.retryAfter != "" {
+				w.Header().Set("Retry-After", c.retryAfter)
+			}
+			w.WriteHeader(c.status)
+		}))
+
+		client := segment.NewClient(segment.ClientConfig{
+			Workspace:    "myworkspace",
+			BaseURL:      server.URL,
+			MaxRetries:   2,
+			RetryMaxWait: time.Millisecond,
+		})
+
+		if _, err := client.CreateSource(context.Background(), "ios", "catalog/sources/ios"); err == nil {
+			t.Errorf("expected error for %d", c.status)
+		}
+		if attempts != c.attempts {
+			t.Errorf("invalid number of attempts for %d (Retry-After %q): expected: %d, actual: %d", c.status, c.retryAfter, c.attempts, attempts)
+		}
+		server.Close()
+	}
+}
+
+func TestClient_noRetryOnPermanent500(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"error": "runtime error: invalid memory address or nil pointer dereference", "code": 13}`)
+	}))
+	defer server.Close()
+
+	client := segment.NewClient(segment.ClientConfig{
+		Workspace:    "myworkspace",
+		BaseURL:      server.URL,
+		MaxRetries:   3,
+		RetryMaxWait: time.Millisecond,
+	})
+
+	_, err := client.GetSourceConfig(context.Background(), "ios")
+	if !segment.Is500NilDereferenceErr(err) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("invalid number of attempts: expected: %d, actual: %d", 1, attempts)
+	}
+}
+
+func TestClient_maxConcurrentRequests(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		fmt.Fprint(w, `{"name": "workspaces/myworkspace/sources/ios"}`)
+	}))
+	defer server.Close()
+
+	client := segment.NewClient(segment.ClientConfig{
+		Workspace:             "myworkspace",
+		BaseURL:               server.URL,
+		MaxConcurrentRequests: 2,
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetSource(context.Background(), "ios"); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Errorf("too many concurrent requests: expected at most: %d, actual: %d", 2, maxInFlight)
+	}
+}
+
+func TestClient_requestsPerSecond(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "workspaces/myworkspace/sources/ios"}`)
+	}))
+	defer server.Close()
+
+	client := segment.NewClient(segment.ClientConfig{
+		Workspace:         "myworkspace",
+		BaseURL:           server.URL,
+		RequestsPerSecond: 20,
+	})
+
+	start := time.Now()
+	for i := 0; i < 30; i++ {
+		if _, err := client.GetSource(context.Background(), "ios"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	// the first 20 requests use the burst, the remaining 10 need at least half a second
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("requests were not rate limited: 30 requests took %s", elapsed)
+	}
+}
+
+func TestClient_cancelledDuringRetry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := segment.NewClient(segment.ClientConfig{
+		Workspace:    "myworkspace",
+		BaseURL:      server.URL,
+		MaxRetries:   3,
+		RetryMaxWait: time.Minute,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetSource(ctx, "ios")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded error, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("request was not cancelled, took %s", elapsed)
+	}
+}
+
+func TestClient_listCatalogSources(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/v1beta/catalog/sources" {
+			t.Errorf("invalid request path: %q", r.URL.Path)
+		}
+		switch r.URL.Query().Get("page_token") {
+		case "":
+			fmt.Fprint(w, `{"sources": [{"name": "catalog/sources/ios"}], "next_page_token": "next"}`)
+		case "next":
+			fmt.Fprint(w, `{"sources": [{"name": "catalog/sources/net"}], "next_page_token": ""}`)
+		default:
+			t.Errorf("invalid page token: %q", r.URL.Query().Get("page_token"))
+		}
+	}))
+	defer server.Close()
+
+	client := segment.NewClient(segment.ClientConfig{Workspace: "myworkspace", BaseURL: server.URL})
+
+	for i := 0; i < 2; i++ {
+		sources, err := client.ListCatalogSources(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(sources) != 2 || sources[0].Name != "catalog/sources/ios" || sources[1].Name != "catalog/sources/net" {
+			t.Errorf("invalid catalog sources: %v", sources)
+		}
+	}
+	// the second call is served from the cache
+	if requests != 2 {
+		t.Errorf("invalid number of requests: expected: %d, actual: %d", 2, requests)
+	}
+}
+
+func TestClient_listCatalogDestinations(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/v1beta/catalog/destinations" {
+			t.Errorf("invalid request path: %q", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"destinations": [{
+			"name": "catalog/destinations/webhooks",
+			"connection_modes": ["CLOUD"],
+			"settings": [{"name": "sharedSecret", "type": "string", "required": false, "default": ""}]
+		}]}`)
+	}))
+	defer server.Close()
+
+	client := segment.NewClient(segment.ClientConfig{Workspace: "myworkspace", BaseURL: server.URL})
+
+	for i := 0; i < 2; i++ {
+		destinations, err := client.ListCatalogDestinations(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		expected := []segment.CatalogDestination{{
+			Name:            "catalog/destinations/webhooks",
+			ConnectionModes: []string{"CLOUD"},
+			Settings:        []segment.CatalogOption{{Name: "sharedSecret", Type: "string", Default: ""}},
+		}}
+		if !cmp.Equal(destinations, expected) {
+			t.Errorf("invalid catalog destinations: %s", cmp.Diff(expected, destinations))
+		}
+	}
+	// the second call is served from the cache
+	if requests != 1 {
+		t.Errorf("invalid number of requests: expected: %d, actual: %d", 1, requests)
+	}
+}
+
+func TestClient_trackingPlanPaths(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	client := segment.NewClient(segment.ClientConfig{Workspace: "myworkspace", BaseURL: server.URL})
+	ctx := context.Background()
+	if _, err := client.ListTrackingPlans(ctx); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := client.GetTrackingPlan(ctx, "rs_123"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// the paths segment-config-go requests, after trimming their trailing slash
+	expected := []string{
+		"GET /v1beta/workspaces/myworkspace/tracking-plans",
+		"GET /v1beta/workspaces/myworkspace/tracking-plans/rs_123",
+	}
+	if !cmp.Equal(requests, expected) {
+		t.Errorf("invalid requests: %s", cmp.Diff(expected, requests))
+	}
+}
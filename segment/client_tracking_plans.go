@@ -0,0 +1,138 @@
+package segment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/forteilgmbh/segment-config-go/segment"
+	"net/http"
+	"strings"
+)
+
+const trackingPlansEndpoint = "tracking-plans"
+
+type trackingPlanCreateRequest struct {
+	TrackingPlan segment.TrackingPlan `json:"tracking_plan,omitempty"`
+}
+
+type trackingPlanUpdateRequest struct {
+	UpdateMask   segment.UpdateMask   `json:"update_mask,omitempty"`
+	TrackingPlan segment.TrackingPlan `json:"tracking_plan,omitempty"`
+}
+
+type trackingPlanSourceConnectionCreateRequest struct {
+	Name string `json:"source_name"`
+}
+
+var trackingPlanUpdateMask = segment.UpdateMask{Paths: []string{"tracking_plan.display_name", "tracking_plan.rules"}}
+
+// trackingPlansPath omits the trailing slash of the tracking plan paths of segment-config-go,
+// which trims it in doRequest anyway, so that the requests are the same
+func (c *Client) trackingPlansPath() string {
+	return fmt.Sprintf("%s/%s/%s", workspacesEndpoint, c.Workspace, trackingPlansEndpoint)
+}
+
+func (c *Client) trackingPlanPath(planId string) string {
+	return fmt.Sprintf("%s/%s", c.trackingPlansPath(), planId)
+}
+
+// ListTrackingPlans lists all the tracking plans of the workspace
+func (c *Client) ListTrackingPlans(ctx context.Context) (segment.TrackingPlans, error) {
+	var tps segment.TrackingPlans
+	data, err := c.doRequest(ctx, http.MethodGet, c.trackingPlansPath(), nil)
+	if err != nil {
+		return tps, err
+	}
+	if err := json.Unmarshal(data, &tps); err != nil {
+		return tps, fmt.Errorf("failed to unmarshal tracking plans response: %w", err)
+	}
+	return tps, nil
+}
+
+// GetTrackingPlan returns a tracking plan
+func (c *Client) GetTrackingPlan(ctx context.Context, planId string) (segment.TrackingPlan, error) {
+	var tp segment.TrackingPlan
+	data, err := c.doRequest(ctx, http.MethodGet, c.trackingPlanPath(planId), nil)
+	if err != nil {
+		return tp, err
+	}
+	if err := json.Unmarshal(data, &tp); err != nil {
+		return tp, fmt.Errorf("failed to unmarshal tracking plan response: %w", err)
+	}
+	return tp, nil
+}
+
+// CreateTrackingPlan creates a tracking plan
+func (c *Client) CreateTrackingPlan(ctx context.Context, plan segment.TrackingPlan) (segment.TrackingPlan, error) {
+	var tp segment.TrackingPlan
+	data, err := c.doRequest(ctx, http.MethodPost, c.trackingPlansPath(), trackingPlanCreateRequest{plan})
+	if err != nil {
+		return tp, err
+	}
+	if err := json.Unmarshal(data, &tp); err != nil {
+		return tp, fmt.Errorf("failed to unmarshal tracking plan response: %w", err)
+	}
+	return tp, nil
+}
+
+// UpdateTrackingPlan updates display name and rules of a tracking plan
+func (c *Client) UpdateTrackingPlan(ctx context.Context, planId string, plan segment.TrackingPlan) (segment.TrackingPlan, error) {
+	var tp segment.TrackingPlan
+	req := trackingPlanUpdateRequest{
+		UpdateMask:   trackingPlanUpdateMask,
+		TrackingPlan: plan,
+	}
+	data, err := c.doRequest(ctx, http.MethodPut, c.trackingPlanPath(planId), req)
+	if err != nil {
+		return tp, err
+	}
+	if err := json.Unmarshal(data, &tp); err != nil {
+		return tp, fmt.Errorf("failed to unmarshal tracking plan response: %w", err)
+	}
+	return tp, nil
+}
+
+// DeleteTrackingPlan deletes a tracking plan
+func (c *Client) DeleteTrackingPlan(ctx context.Context, planId string) error {
+	_, err := c.doRequest(ctx, http.MethodDelete, c.trackingPlanPath(planId), nil)
+	return err
+}
+
+// CreateTrackingPlanSourceConnection associates a source to a tracking plan
+func (c *Client) CreateTrackingPlanSourceConnection(ctx context.Context, planId string, srcSlug string) error {
+	req := trackingPlanSourceConnectionCreateRequest{Name: c.sourcePath(srcSlug)}
+	data, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/source-connections", c.trackingPlanPath(planId)), req)
+	if err != nil {
+		return err
+	}
+	var result segment.TrackingPlanSourceConnection
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("unexpected response body: %s", string(data))
+	}
+	return nil
+}
+
+// ListTrackingPlanSources lists all the sources associated with a tracking plan
+func (c *Client) ListTrackingPlanSources(ctx context.Context, planId string) ([]segment.TrackingPlanSourceConnection, error) {
+	var connections segment.TrackingPlanSourceConnections
+	data, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s/source-connections", c.trackingPlanPath(planId)), nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &connections); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tracking plan source connections response: %w", err)
+	}
+	return connections.Connections, nil
+}
+
+// DeleteTrackingPlanSourceConnection removes the connection between a source and a tracking plan
+func (c *Client) DeleteTrackingPlanSourceConnection(ctx context.Context, planId string, srcSlug string) error {
+	data, err := c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/source-connections/%s", c.trackingPlanPath(planId), srcSlug), nil)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(string(data)) != "{}" {
+		return fmt.Errorf("unexpected response body: %s", string(data))
+	}
+	return nil
+}
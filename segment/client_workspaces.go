@@ -0,0 +1,29 @@
+package segment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/forteilgmbh/segment-config-go/segment"
+	"net/http"
+)
+
+// Workspace extends the workspace of the client library with the properties which it does not support
+type Workspace struct {
+	segment.Workspace
+	Plan     string   `json:"plan,omitempty"`
+	Features []string `json:"features,omitempty"`
+}
+
+// GetWorkspace returns information about the workspace of the client
+func (c *Client) GetWorkspace(ctx context.Context) (Workspace, error) {
+	var w Workspace
+	data, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%s", workspacesEndpoint, c.Workspace), nil)
+	if err != nil {
+		return w, err
+	}
+	if err := json.Unmarshal(data, &w); err != nil {
+		return w, fmt.Errorf("failed to unmarshal workspace response: %w", err)
+	}
+	return w, nil
+}
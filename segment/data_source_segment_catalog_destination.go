@@ -0,0 +1,117 @@
+package segment
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strings"
+)
+
+func dataSourceSegmentCatalogDestination() *schema.Resource {
+	s := catalogDestinationDataSourceSchema()
+	s["name"].Required = true
+	s["name"].Computed = false
+
+	return &schema.Resource{
+		Description: "Looks up an entry of the destination catalog by its name",
+		Schema:      s,
+		ReadContext: dataSourceSegmentCatalogDestinationRead,
+	}
+}
+
+// catalogDestinationDataSourceSchema returns the attributes of a destination catalog entry exposed by the data sources, all computed
+func catalogDestinationDataSourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Description: `Catalog name of the destination (e.g. "catalog/destinations/webhooks")`,
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"slug": {
+			Description: `Slug of the destination, as used in segment_destination (e.g. "webhooks")`,
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"display_name": {
+			Description: "Display name of the destination",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"description": {
+			Description: "Description of the destination",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"categories": {
+			Description: "Categories of the destination",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"connection_modes": {
+			Description: `Connection modes supported by the destination (e.g. "CLOUD" or "DEVICE")`,
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"settings": {
+			Description: `Options accepted in the configs of the destination, each one set in a config named "<destination-name>/config/<option-name>"`,
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: catalogOptionSchema(),
+			},
+		},
+	}
+}
+
+func dataSourceSegmentCatalogDestinationRead(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	name := r.Get("name").(string)
+
+	catalog, err := client.ListCatalogDestinations(c)
+	if err != nil {
+		return apiErrorDiag(err, "cannot read the destination catalog")
+	}
+
+	for _, d := range catalog {
+		if d.Name != name {
+			continue
+		}
+		destination, err := flattenCatalogDestination(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for k, v := range destination {
+			if err := r.Set(k, v); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		r.SetId(d.Name)
+		return nil
+	}
+
+	return diag.Errorf("%q does not exist in the destination catalog", name)
+}
+
+func flattenCatalogDestination(d CatalogDestination) (map[string]interface{}, error) {
+	settings, err := flattenCatalogOptions(d.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("cannot flatten settings of catalog destination %q: %w", d.Name, err)
+	}
+
+	return map[string]interface{}{
+		"name":             d.Name,
+		"slug":             strings.TrimPrefix(d.Name, catalogDestinationsPath+"/"),
+		"display_name":     d.DisplayName,
+		"description":      d.Description,
+		"categories":       d.Categories,
+		"connection_modes": d.ConnectionModes,
+		"settings":         settings,
+	}, nil
+}
@@ -0,0 +1,49 @@
+package segment
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSegmentCatalogDestinations() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists all the entries of the destination catalog",
+		Schema: map[string]*schema.Schema{
+			"destinations": {
+				Description: "The entries of the destination catalog",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: catalogDestinationDataSourceSchema(),
+				},
+			},
+		},
+		ReadContext: dataSourceSegmentCatalogDestinationsRead,
+	}
+}
+
+func dataSourceSegmentCatalogDestinationsRead(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+
+	catalog, err := client.ListCatalogDestinations(c)
+	if err != nil {
+		return apiErrorDiag(err, "cannot read the destination catalog")
+	}
+
+	result := make([]interface{}, 0, len(catalog))
+	for _, d := range catalog {
+		destination, err := flattenCatalogDestination(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		result = append(result, destination)
+	}
+
+	if err := r.Set("destinations", result); err != nil {
+		return diag.FromErr(err)
+	}
+	r.SetId(catalogDestinationsPath)
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package segment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSegmentCatalogSource() *schema.Resource {
+	s := catalogSourceDataSourceSchema()
+	s["name"].Required = true
+	s["name"].Computed = false
+
+	return &schema.Resource{
+		Description: "Looks up an entry of the source catalog by its name",
+		Schema:      s,
+		ReadContext: dataSourceSegmentCatalogSourceRead,
+	}
+}
+
+// catalogSourceDataSourceSchema returns the attributes of a source catalog entry exposed by the data sources, all computed
+func catalogSourceDataSourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Description: `Catalog name of the source (e.g. "catalog/sources/javascript")`,
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"display_name": {
+			Description: "Display name of the source",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"description": {
+			Description: "Description of the source",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"categories": {
+			Description: "Categories of the source",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"settings": {
+			Description: "Options accepted in the settings of the source",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: catalogOptionSchema(),
+			},
+		},
+	}
+}
+
+// catalogOptionSchema returns the attributes of a setting of a catalog entry
+func catalogOptionSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Description: "Name of the option",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"display_name": {
+			Description: "Display name of the option",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"description": {
+			Description: "Description of the option",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"type": {
+			Description: `Type of the option (e.g. "string", "boolean", "map" or "mixed")`,
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"required": {
+			Description: "Whether the option has to be set",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"default": {
+			Description: "Default value of the option as JSON-encoded string, empty if there is none",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+}
+
+func dataSourceSegmentCatalogSourceRead(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	name := r.Get("name").(string)
+
+	catalog, err := client.ListCatalogSources(c)
+	if err != nil {
+		return apiErrorDiag(err, "cannot read the source catalog")
+	}
+
+	for _, s := range catalog {
+		if s.Name != name {
+			continue
+		}
+		source, err := flattenCatalogSource(s)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for k, v := range source {
+			if err := r.Set(k, v); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		r.SetId(s.Name)
+		return nil
+	}
+
+	return diag.Errorf("%q does not exist in the source catalog", name)
+}
+
+func flattenCatalogSource(s CatalogSource) (map[string]interface{}, error) {
+	settings, err := flattenCatalogOptions(s.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("cannot flatten settings of catalog source %q: %w", s.Name, err)
+	}
+
+	return map[string]interface{}{
+		"name":         s.Name,
+		"display_name": s.DisplayName,
+		"description":  s.Description,
+		"categories":   s.Categories,
+		"settings":     settings,
+	}, nil
+}
+
+func flattenCatalogOptions(options []CatalogOption) ([]interface{}, error) {
+	result := make([]interface{}, 0, len(options))
+	for _, o := range options {
+		def := ""
+		if o.Default != nil {
+			j, err := json.Marshal(o.Default)
+			if err != nil {
+				return nil, fmt.Errorf("cannot encode default value of %q: %w", o.Name, err)
+			}
+			def = string(j)
+		}
+		result = append(result, map[string]interface{}{
+			"name":         o.Name,
+			"display_name": o.DisplayName,
+			"description":  o.Description,
+			"type":         o.Type,
+			"required":     o.Required,
+			"default":      def,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,49 @@
+package segment
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSegmentCatalogSources() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists all the entries of the source catalog",
+		Schema: map[string]*schema.Schema{
+			"sources": {
+				Description: "The entries of the source catalog",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: catalogSourceDataSourceSchema(),
+				},
+			},
+		},
+		ReadContext: dataSourceSegmentCatalogSourcesRead,
+	}
+}
+
+func dataSourceSegmentCatalogSourcesRead(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+
+	catalog, err := client.ListCatalogSources(c)
+	if err != nil {
+		return apiErrorDiag(err, "cannot read the source catalog")
+	}
+
+	result := make([]interface{}, 0, len(catalog))
+	for _, s := range catalog {
+		source, err := flattenCatalogSource(s)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		result = append(result, source)
+	}
+
+	if err := r.Set("sources", result); err != nil {
+		return diag.FromErr(err)
+	}
+	r.SetId(catalogSourcesPath)
+
+	return nil
+}
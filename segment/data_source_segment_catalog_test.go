@@ -0,0 +1,67 @@
+package segment_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestAccDataSourceSegmentCatalog_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSegmentCatalogConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.segment_catalog_source.test", "id", "catalog/sources/net"),
+					resource.TestCheckResourceAttrSet("data.segment_catalog_source.test", "display_name"),
+					resource.TestCheckResourceAttr("data.segment_catalog_destination.test", "id", "catalog/destinations/webhooks"),
+					resource.TestCheckResourceAttr("data.segment_catalog_destination.test", "slug", "webhooks"),
+					resource.TestCheckTypeSetElemAttr("data.segment_catalog_destination.test", "connection_modes.*", "CLOUD"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.segment_catalog_destination.test", "settings.*", map[string]string{
+						"name": "sharedSecret",
+						"type": "string",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.segment_catalog_sources.test", "sources.*", map[string]string{
+						"name": "catalog/sources/net",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.segment_catalog_destinations.test", "destinations.*", map[string]string{
+						"name": "catalog/destinations/webhooks",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceSegmentCatalog_notFound(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "segment_catalog_destination" "test" {
+  name = "catalog/destinations/tf-testacc-does-not-exist"
+}
+`,
+				ExpectError: regexp.MustCompile(`does not exist in the destination catalog`),
+			},
+		},
+	})
+}
+
+const testAccDataSourceSegmentCatalogConfig_basic = `
+data "segment_catalog_source" "test" {
+  name = "catalog/sources/net"
+}
+
+data "segment_catalog_sources" "test" {}
+
+data "segment_catalog_destination" "test" {
+  name = "catalog/destinations/webhooks"
+}
+
+data "segment_catalog_destinations" "test" {}
+`
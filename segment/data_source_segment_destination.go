@@ -0,0 +1,127 @@
+package segment
+
+import (
+	"context"
+	"github.com/forteilgmbh/segment-config-go/segment"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSegmentDestination() *schema.Resource {
+	s := destinationDataSourceSchema()
+	s["slug"].Required = true
+	s["slug"].Computed = false
+	s["source_slug"].Required = true
+	s["source_slug"].Computed = false
+
+	return &schema.Resource{
+		Description: "Looks up an existing destination of a source by its slug",
+		Schema:      s,
+		ReadContext: dataSourceSegmentDestinationRead,
+	}
+}
+
+// destinationDataSourceSchema returns the attributes of a destination exposed by the data sources, all computed
+func destinationDataSourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"slug": {
+			Description: `Short name of the destination (e.g. "webhooks")`,
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"source_slug": {
+			Description: `Short name of the source (e.g. "ios")`,
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name": {
+			Description: `Full name of the destination (e.g. "workspaces/myworkspace/sources/ios/destinations/webhooks")`,
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"connection_mode": {
+			Description: "Connection mode of the destination",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"enabled": {
+			Description: "Delivery enabled for the destination",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"configs": {
+			Description: "Config of the destination",
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"value": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"sensitive_value": {
+						Description: "Value of the config if it is secret according to its type or the catalog",
+						Type:        schema.TypeString,
+						Computed:    true,
+						Sensitive:   true,
+					},
+					"type": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSegmentDestinationRead(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	slug := r.Get("slug").(string)
+	srcSlug := r.Get("source_slug").(string)
+
+	d, err := client.GetDestination(c, srcSlug, slug)
+	if err != nil {
+		return apiErrorDiag(err, "cannot read destination %q of source %q", slug, srcSlug)
+	}
+
+	destination, err := flattenDestination(c, client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for k, v := range destination {
+		if err := r.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	r.SetId(d.Name)
+
+	return nil
+}
+
+func flattenDestination(c context.Context, client *Client, d segment.Destination) (map[string]interface{}, error) {
+	srcSlug, slug, err := DestinationNameToSlugs(client.Workspace, d.Name)
+	if err != nil {
+		return nil, err
+	}
+	options := readSensitiveDestinationOptions(c, client, slug)
+	configs, err := flattenDestinationConfigs(d.Configs, func(dc segment.DestinationConfig) destinationConfigFormat {
+		return destinationConfigFormat{sensitive: isSensitiveDestinationConfig(dc, options), fullName: true}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"slug":            slug,
+		"source_slug":     srcSlug,
+		"name":            d.Name,
+		"connection_mode": d.ConnectionMode,
+		"enabled":         d.Enabled,
+		"configs":         configs,
+	}, nil
+}
@@ -0,0 +1,49 @@
+package segment_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccDataSourceSegmentDestination_basic(t *testing.T) {
+	srcSlug := acctest.RandomWithPrefix("tf-testacc-ds-dst")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckSegmentDestinationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSegmentDestinationConfig_basic(srcSlug),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.segment_destination.test", "id", "segment_destination.test", "id"),
+					resource.TestCheckResourceAttrPair("data.segment_destination.test", "name", "segment_destination.test", "id"),
+					resource.TestCheckResourceAttr("data.segment_destination.test", "slug", "webhooks"),
+					resource.TestCheckResourceAttr("data.segment_destination.test", "source_slug", srcSlug),
+					resource.TestCheckResourceAttr("data.segment_destination.test", "connection_mode", "UNSPECIFIED"),
+					resource.TestCheckResourceAttr("data.segment_destination.test", "enabled", "true"),
+					testAccCheckDestinationConfigs_webhook("data.segment_destination.test", srcSlug, "https://example.com/api/v1"),
+					resource.TestCheckResourceAttr("data.segment_destinations.test", "destinations.#", "1"),
+					resource.TestCheckResourceAttr("data.segment_destinations.test", "destinations.0.slug", "webhooks"),
+					resource.TestCheckResourceAttr("data.segment_destinations.test", "destinations.0.configs.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceSegmentDestinationConfig_basic(srcSlug string) string {
+	return configCompose(
+		testAccSegmentDestinationConfig_webhook(srcSlug, true, "https://example.com/api/v1"),
+		`
+data "segment_destination" "test" {
+  source_slug = segment_destination.test.source_slug
+  slug        = segment_destination.test.slug
+}
+
+data "segment_destinations" "test" {
+  source_slug = segment_destination.test.source_slug
+}
+`)
+}
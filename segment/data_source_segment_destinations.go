@@ -0,0 +1,55 @@
+package segment
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSegmentDestinations() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the destinations of a source",
+		Schema: map[string]*schema.Schema{
+			"source_slug": {
+				Description: `Short name of the source (e.g. "ios")`,
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"destinations": {
+				Description: "The destinations of the source",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: destinationDataSourceSchema(),
+				},
+			},
+		},
+		ReadContext: dataSourceSegmentDestinationsRead,
+	}
+}
+
+func dataSourceSegmentDestinationsRead(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	srcSlug := r.Get("source_slug").(string)
+
+	destinations, err := client.ListDestinations(c, srcSlug)
+	if err != nil {
+		return apiErrorDiag(err, "cannot list destinations of source %q", srcSlug)
+	}
+
+	result := make([]interface{}, 0, len(destinations.Destinations))
+	for _, d := range destinations.Destinations {
+		destination, err := flattenDestination(c, client, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		result = append(result, destination)
+	}
+
+	if err := r.Set("destinations", result); err != nil {
+		return diag.FromErr(err)
+	}
+	r.SetId(client.destinationsPath(srcSlug))
+
+	return nil
+}
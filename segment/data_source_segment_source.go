@@ -0,0 +1,145 @@
+package segment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"time"
+)
+
+func dataSourceSegmentSource() *schema.Resource {
+	s := sourceDataSourceSchema()
+	s["slug"].Required = true
+	s["slug"].Computed = false
+
+	return &schema.Resource{
+		Description: "Looks up an existing source by its slug",
+		Schema:      s,
+		ReadContext: dataSourceSegmentSourceRead,
+	}
+}
+
+// sourceDataSourceSchema returns the attributes of a source exposed by the data sources, all computed
+func sourceDataSourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"slug": {
+			Description: `Short name of the source (e.g. "ios")`,
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name": {
+			Description: `Full name of the source (e.g. "workspaces/myworkspace/sources/ios")`,
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"catalog_name": {
+			Description: "Catalog name of the source",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"catalog_id": {
+			Description: "ID of the catalog entry of the source",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"display_name": {
+			Description: "Display name of the source",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"enabled": {
+			Description: "Whether the source accepts data",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"labels": {
+			Description: "Labels of the source",
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"settings": {
+			Description: "Settings of the source as JSON-encoded object",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"write_keys": {
+			Description: "Write keys of the source",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Sensitive:   true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"create_time": {
+			Description: "Time at which the source was created, in RFC 3339 format",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+}
+
+func dataSourceSegmentSourceRead(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	slug := r.Get("slug").(string)
+
+	s, err := client.GetSource(c, slug)
+	if err != nil {
+		return apiErrorDiag(err, "cannot read source %q", slug)
+	}
+
+	source, err := flattenSource(client.Workspace, s)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for k, v := range source {
+		if err := r.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	r.SetId(s.Name)
+
+	return nil
+}
+
+func flattenSource(workspace string, s Source) (map[string]interface{}, error) {
+	slug, err := SourceNameToSlug(workspace, s.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := ""
+	if s.Settings != nil {
+		j, err := json.Marshal(s.Settings)
+		if err != nil {
+			return nil, fmt.Errorf("cannot flatten settings of source %q: %w", slug, err)
+		}
+		settings = string(j)
+	}
+	createTime := ""
+	if !s.CreateTime.IsZero() {
+		createTime = s.CreateTime.Format(time.RFC3339)
+	}
+
+	source := map[string]interface{}{
+		"slug":         slug,
+		"name":         s.Name,
+		"catalog_name": s.CatalogName,
+		"catalog_id":   s.CatalogId,
+		"display_name": s.DisplayName,
+		"labels":       s.Labels,
+		"settings":     settings,
+		"write_keys":   s.WriteKeys,
+		"create_time":  createTime,
+	}
+	// like in the resource, enabled is left unset when the API omits it
+	if s.Enabled != nil {
+		source["enabled"] = *s.Enabled
+	}
+	return source, nil
+}
@@ -0,0 +1,112 @@
+package segment_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/forteilgmbh/terraform-provider-segment/segment"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"net/http"
+	"testing"
+)
+
+func TestAccDataSourceSegmentSource_basic(t *testing.T) {
+	srcSlug := acctest.RandomWithPrefix("tf-testacc-ds-src")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckSegmentSourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSegmentSourceConfig_basic(srcSlug),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.segment_source.test", "id", "segment_source.test", "id"),
+					resource.TestCheckResourceAttrPair("data.segment_source.test", "name", "segment_source.test", "id"),
+					resource.TestCheckResourceAttr("data.segment_source.test", "slug", srcSlug),
+					resource.TestCheckResourceAttr("data.segment_source.test", "catalog_name", "catalog/sources/net"),
+					resource.TestCheckResourceAttrPair("data.segment_source.test", "catalog_id", "segment_source.test", "catalog_id"),
+					resource.TestCheckResourceAttrPair("data.segment_source.test", "write_keys.0", "segment_source.test", "write_keys.0"),
+					resource.TestCheckResourceAttr("data.segment_source.test", "labels.environment", "test"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceSegmentSources_filters(t *testing.T) {
+	srcSlug := acctest.RandomWithPrefix("tf-testacc-ds-srcs")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckSegmentSourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSegmentSourcesConfig_filters(srcSlug),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.segment_sources.test", "sources.#", "1"),
+					resource.TestCheckResourceAttr("data.segment_sources.test", "sources.0.slug", srcSlug),
+					resource.TestCheckResourceAttr("data.segment_sources.test", "sources.0.catalog_name", "catalog/sources/net"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceSegmentSourceConfig_basic(srcSlug string) string {
+	return fmt.Sprintf(`
+resource "segment_source" "test" {
+  slug         = %q
+  catalog_name = "catalog/sources/net"
+
+  labels = {
+    environment = "test"
+  }
+}
+
+data "segment_source" "test" {
+  slug = segment_source.test.slug
+}
+`, srcSlug)
+}
+
+func testAccDataSourceSegmentSourcesConfig_filters(srcSlug string) string {
+	return fmt.Sprintf(`
+resource "segment_source" "test" {
+  slug         = %q
+  catalog_name = "catalog/sources/net"
+
+  labels = {
+    test-run = %q
+  }
+}
+
+data "segment_sources" "test" {
+  catalog_name = segment_source.test.catalog_name
+  labels       = segment_source.test.labels
+}
+`, srcSlug, srcSlug)
+}
+
+func TestDataSourceSegmentSource_withoutEnabled(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// sources created before "enabled" was managed, as returned by an API which omits the flag
+		fmt.Fprint(w, `{"name": "workspaces/myworkspace/sources/ios", "catalog_name": "catalog/sources/ios"}`)
+	}, segment.ClientConfig{})
+	ds := segment.Provider().DataSourcesMap["segment_source"]
+	ctx := context.Background()
+
+	diff, err := ds.Diff(ctx, nil, terraform.NewResourceConfigRaw(map[string]interface{}{"slug": "ios"}), client)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %s", err)
+	}
+	state, diags := ds.ReadDataApply(ctx, diff, client)
+	if diags.HasError() {
+		t.Fatalf("unexpected read error: %v", diags)
+	}
+	if v, ok := state.Attributes["enabled"]; ok {
+		t.Errorf("unexpected enabled: %q", v)
+	}
+}
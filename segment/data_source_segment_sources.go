@@ -0,0 +1,79 @@
+package segment
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSegmentSources() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the sources of the workspace",
+		Schema: map[string]*schema.Schema{
+			"catalog_name": {
+				Description: `Only list the sources with the given catalog name (e.g. "catalog/sources/javascript")`,
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"labels": {
+				Description: "Only list the sources having all the given labels",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"sources": {
+				Description: "The matching sources",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: sourceDataSourceSchema(),
+				},
+			},
+		},
+		ReadContext: dataSourceSegmentSourcesRead,
+	}
+}
+
+func dataSourceSegmentSourcesRead(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	catName := r.Get("catalog_name").(string)
+	labels := r.Get("labels").(map[string]interface{})
+
+	sources, err := client.ListSources(c)
+	if err != nil {
+		return apiErrorDiag(err, "cannot list sources")
+	}
+
+	result := make([]interface{}, 0)
+	for _, s := range sources.Sources {
+		if catName != "" && s.CatalogName != catName {
+			continue
+		}
+		if !sourceHasLabels(s, labels) {
+			continue
+		}
+		source, err := flattenSource(client.Workspace, s)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		result = append(result, source)
+	}
+
+	if err := r.Set("sources", result); err != nil {
+		return diag.FromErr(err)
+	}
+	r.SetId(client.sourcesPath())
+
+	return nil
+}
+
+func sourceHasLabels(s Source, labels map[string]interface{}) bool {
+	for k, v := range labels {
+		if actual, ok := s.Labels[k]; !ok || actual != v.(string) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,170 @@
+package segment
+
+import (
+	"context"
+	"fmt"
+	"github.com/forteilgmbh/segment-config-go/segment"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strings"
+)
+
+func dataSourceSegmentTrackingPlan() *schema.Resource {
+	return &schema.Resource{
+		Description: "Looks up an existing tracking plan by its ID or its display name",
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description:  `ID of the tracking plan (e.g. "rs_123")`,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "display_name"},
+			},
+			"display_name": {
+				Description:  "Display name of the tracking plan, which has to be unique in the workspace",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "display_name"},
+			},
+			"name": {
+				Description: `Full name of the tracking plan (e.g. "workspaces/myworkspace/tracking-plans/rs_123")`,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"rules_global": {
+				Description: "Rules applied to all messages as JSON-encoded string",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"rules_identify": {
+				Description: "Rules applied to Identify calls as JSON-encoded string",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"rules_group": {
+				Description: "Rules applied to Group calls as JSON-encoded string",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"rules_events": {
+				Description: "Rules applied to Track calls as list of JSON-encoded strings",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"source_slugs": {
+				Description: "Short names of the sources connected to the tracking plan",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		ReadContext: dataSourceSegmentTrackingPlanRead,
+	}
+}
+
+func dataSourceSegmentTrackingPlanRead(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+
+	var planId string
+	if id, ok := r.GetOk("id"); ok {
+		planId = id.(string)
+		names, err := getTrackingPlansNames(c, client)
+		if err != nil {
+			return apiErrorDiag(err, "cannot read tracking plan %q", planId)
+		}
+		if _, ok := names[planId]; !ok {
+			return diag.Errorf("tracking plan %q not found", planId)
+		}
+	} else {
+		displayName := r.Get("display_name").(string)
+		id, err := findTrackingPlanIdByDisplayName(c, client, displayName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		planId = id
+	}
+
+	trackingPlan, err := client.GetTrackingPlan(c, planId)
+	if err != nil {
+		return apiErrorDiag(err, "cannot read tracking plan %q", planId)
+	}
+	connections, err := client.ListTrackingPlanSources(c, planId)
+	if err != nil {
+		return apiErrorDiag(err, "cannot list sources of tracking plan %q", planId)
+	}
+	srcSlugs := make([]interface{}, 0, len(connections))
+	for _, conn := range connections {
+		srcSlug, err := SourceNameToSlug(client.Workspace, conn.Source)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		srcSlugs = append(srcSlugs, srcSlug)
+	}
+
+	if err := r.Set("display_name", trackingPlan.DisplayName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := r.Set("name", trackingPlan.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setTrackingPlanDataSourceRules(r, trackingPlan.Rules); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := r.Set("source_slugs", srcSlugs); err != nil {
+		return diag.FromErr(err)
+	}
+	r.SetId(planId)
+
+	return nil
+}
+
+// findTrackingPlanIdByDisplayName returns the ID of the only tracking plan with the given display name
+func findTrackingPlanIdByDisplayName(c context.Context, client *Client, displayName string) (string, error) {
+	plans, err := client.ListTrackingPlans(c)
+	if err != nil {
+		return "", fmt.Errorf("cannot list tracking plans: %w", err)
+	}
+	var ids []string
+	for _, p := range plans.TrackingPlans {
+		if p.DisplayName != displayName {
+			continue
+		}
+		id, err := TrackingPlanNameToId(client.Workspace, p.Name)
+		if err != nil {
+			return "", err
+		}
+		ids = append(ids, id)
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("tracking plan with display name %q not found", displayName)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("display name %q matches several tracking plans (%s), use the id instead", displayName, strings.Join(ids, ", "))
+	}
+}
+
+// setTrackingPlanDataSourceRules sets all rules, unlike setTrackingPlanRules which skips the empty ones
+func setTrackingPlanDataSourceRules(r *schema.ResourceData, rules segment.RuleSet) error {
+	if err := r.Set("rules_global", toTfState(rules.Global)); err != nil {
+		return err
+	}
+	if err := r.Set("rules_identify", toTfState(rules.Identify)); err != nil {
+		return err
+	}
+	if err := r.Set("rules_group", toTfState(rules.Group)); err != nil {
+		return err
+	}
+	events := make([]interface{}, 0, len(rules.Events))
+	for _, e := range rules.Events {
+		events = append(events, toTfState(e))
+	}
+	return r.Set("rules_events", events)
+}
@@ -0,0 +1,56 @@
+package segment_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccDataSourceSegmentTrackingPlan_basic(t *testing.T) {
+	srcName := acctest.RandomWithPrefix("tf-testacc-ds-tp")
+	tpName := acctest.RandomWithPrefix("tf-testacc-ds-tp")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckSegmentTrackingPlanSourceConnectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSegmentTrackingPlanConfig_basic(srcName, tpName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.segment_tracking_plan.by_name", "id", "segment_tracking_plan.test", "id"),
+					resource.TestCheckResourceAttrPair("data.segment_tracking_plan.by_name", "name", "segment_tracking_plan.test", "name"),
+					resource.TestCheckResourceAttrPair("data.segment_tracking_plan.by_name", "rules_identify", "segment_tracking_plan.test", "rules_identify"),
+					resource.TestCheckResourceAttr("data.segment_tracking_plan.by_name", "rules_events.#", "0"),
+					resource.TestCheckResourceAttr("data.segment_tracking_plan.by_name", "source_slugs.#", "1"),
+					resource.TestCheckResourceAttr("data.segment_tracking_plan.by_name", "source_slugs.0", srcName),
+					resource.TestCheckResourceAttr("data.segment_tracking_plan.by_id", "display_name", tpName),
+					resource.TestCheckResourceAttrPair("data.segment_tracking_plan.by_id", "rules_identify", "segment_tracking_plan.test", "rules_identify"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.segment_tracking_plans.all", "tracking_plans.*", map[string]string{
+						"display_name": tpName,
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceSegmentTrackingPlanConfig_basic(srcName, tpName string) string {
+	return configCompose(
+		testAccSegmentTrackingPlanSourceConnectionConfig_basic(srcName, tpName),
+		`
+data "segment_tracking_plan" "by_name" {
+  display_name = segment_tracking_plan.test.display_name
+
+  depends_on = [segment_tracking_plan_source_connection.test]
+}
+
+data "segment_tracking_plan" "by_id" {
+  id = segment_tracking_plan.test.id
+}
+
+data "segment_tracking_plans" "all" {
+  depends_on = [segment_tracking_plan.test]
+}
+`)
+}
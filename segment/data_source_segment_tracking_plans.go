@@ -0,0 +1,69 @@
+package segment
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSegmentTrackingPlans() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the tracking plans of the workspace",
+		Schema: map[string]*schema.Schema{
+			"tracking_plans": {
+				Description: "The tracking plans of the workspace",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: `ID of the tracking plan (e.g. "rs_123")`,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: `Full name of the tracking plan (e.g. "workspaces/myworkspace/tracking-plans/rs_123")`,
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"display_name": {
+							Description: "Display name of the tracking plan",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+		ReadContext: dataSourceSegmentTrackingPlansRead,
+	}
+}
+
+func dataSourceSegmentTrackingPlansRead(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+
+	plans, err := client.ListTrackingPlans(c)
+	if err != nil {
+		return apiErrorDiag(err, "cannot list tracking plans")
+	}
+
+	result := make([]interface{}, 0, len(plans.TrackingPlans))
+	for _, p := range plans.TrackingPlans {
+		planId, err := TrackingPlanNameToId(client.Workspace, p.Name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		result = append(result, map[string]interface{}{
+			"id":           planId,
+			"name":         p.Name,
+			"display_name": p.DisplayName,
+		})
+	}
+
+	if err := r.Set("tracking_plans", result); err != nil {
+		return diag.FromErr(err)
+	}
+	r.SetId(client.trackingPlansPath())
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package segment
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"time"
+)
+
+func dataSourceSegmentWorkspace() *schema.Resource {
+	return &schema.Resource{
+		Description: "Returns the workspace the provider is configured with",
+		Schema: map[string]*schema.Schema{
+			"slug": {
+				Description: "Short name of the workspace. If set, reading the data source fails when the provider is configured with another workspace",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"name": {
+				Description: `Full name of the workspace (e.g. "workspaces/myworkspace"), the prefix of the names of all its objects`,
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"display_name": {
+				Description: "Display name of the workspace",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"plan": {
+				Description: "Plan of the workspace, empty if not returned by Segment",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"features": {
+				Description: "Features enabled in the workspace, empty if not returned by Segment",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"create_time": {
+				Description: "Time at which the workspace was created, in RFC 3339 format",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+		ReadContext: dataSourceSegmentWorkspaceRead,
+	}
+}
+
+func dataSourceSegmentWorkspaceRead(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+
+	if slug, ok := r.GetOk("slug"); ok && slug.(string) != client.Workspace {
+		return diag.Errorf("the provider is configured with workspace %q instead of %q", client.Workspace, slug.(string))
+	}
+
+	w, err := client.GetWorkspace(c)
+	if err != nil {
+		return apiErrorDiag(err, "cannot read workspace %q", client.Workspace)
+	}
+
+	if err := r.Set("slug", client.Workspace); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := r.Set("name", w.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := r.Set("display_name", w.DisplayName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := r.Set("plan", w.Plan); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := r.Set("features", w.Features); err != nil {
+		return diag.FromErr(err)
+	}
+	createTime := ""
+	if !w.CreateTime.IsZero() {
+		createTime = w.CreateTime.Format(time.RFC3339)
+	}
+	if err := r.Set("create_time", createTime); err != nil {
+		return diag.FromErr(err)
+	}
+	r.SetId(w.ID)
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package segment_test
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"os"
+	"regexp"
+	"testing"
+)
+
+func TestAccDataSourceSegmentWorkspace_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSegmentWorkspaceConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.segment_workspace.test", "slug", os.Getenv("SEGMENT_WORKSPACE")),
+					resource.TestCheckResourceAttr("data.segment_workspace.test", "name", "workspaces/"+os.Getenv("SEGMENT_WORKSPACE")),
+					resource.TestCheckResourceAttrSet("data.segment_workspace.test", "id"),
+					resource.TestCheckResourceAttrSet("data.segment_workspace.test", "display_name"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceSegmentWorkspace_otherWorkspace(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccDataSourceSegmentWorkspaceConfig_slug, "tf-testacc-other-workspace"),
+				ExpectError: regexp.MustCompile(`the provider is configured with workspace ".+" instead of "tf-testacc-other-workspace"`),
+			},
+		},
+	})
+}
+
+const testAccDataSourceSegmentWorkspaceConfig_basic = `
+data "segment_workspace" "test" {}
+`
+
+const testAccDataSourceSegmentWorkspaceConfig_slug = `
+data "segment_workspace" "test" {
+  slug = %q
+}
+`
@@ -0,0 +1,31 @@
+package segment
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// deletionProtectionSchema is the schema of the "deletion_protection" attribute. It is stored only in state,
+// so changing it never results in API calls.
+func deletionProtectionSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("Whether Terraform is prevented from deleting the %s, including replacing it", kind),
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	}
+}
+
+// checkDeletionProtection returns an error if the deletion protection of the resource is enabled
+func checkDeletionProtection(r *schema.ResourceData, kind, id string) diag.Diagnostics {
+	if !r.Get("deletion_protection").(bool) {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("cannot delete %s %q: deletion protection is enabled", kind, id),
+		Detail: fmt.Sprintf("The %s is protected from deletion, which also prevents replacing it when an argument that forces "+
+			"replacement changes. Set \"deletion_protection\" to false and apply the change before deleting the %s.", kind, kind),
+	}}
+}
@@ -0,0 +1,123 @@
+package segment_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/forteilgmbh/terraform-provider-segment/segment"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"net/http"
+	"testing"
+)
+
+func TestDeletionProtection_noApiCall(t *testing.T) {
+	responses := map[string]string{
+		"/v1beta/workspaces/myworkspace/sources/ios": `{
+			"name": "workspaces/myworkspace/sources/ios", "catalog_name": "catalog/sources/ios", "enabled": true}`,
+		"/v1beta/workspaces/myworkspace/sources/ios/destinations/webhooks": `{
+			"name": "workspaces/myworkspace/sources/ios/destinations/webhooks", "connection_mode": "CLOUD", "enabled": true}`,
+		"/v1beta/workspaces/myworkspace/tracking-plans": `{"tracking_plans": [{
+			"name": "workspaces/myworkspace/tracking-plans/rs_123", "display_name": "plan"}]}`,
+		"/v1beta/workspaces/myworkspace/tracking-plans/rs_123": `{
+			"name": "workspaces/myworkspace/tracking-plans/rs_123", "display_name": "plan"}`,
+	}
+	cases := []struct {
+		resource string
+		id       string
+		// defaults are set on import, as read does not set them
+		defaults map[string]string
+		config   map[string]interface{}
+	}{
+		{
+			resource: "segment_source",
+			id:       "workspaces/myworkspace/sources/ios",
+			defaults: map[string]string{"force_destroy": "false"},
+			config:   map[string]interface{}{"slug": "ios", "catalog_name": "catalog/sources/ios"},
+		},
+		{
+			resource: "segment_destination",
+			id:       "workspaces/myworkspace/sources/ios/destinations/webhooks",
+			defaults: map[string]string{"authoritative_configs": "true"},
+			config:   map[string]interface{}{"slug": "webhooks", "source_slug": "ios", "connection_mode": "CLOUD"},
+		},
+		{
+			resource: "segment_tracking_plan",
+			id:       "rs_123",
+			config:   map[string]interface{}{"display_name": "plan"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.resource, func(t *testing.T) {
+			res, client := newTestResource(t, c.resource, func(w http.ResponseWriter, r *http.Request) {
+				response, ok := responses[r.URL.Path]
+				if r.Method != http.MethodGet || !ok {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				fmt.Fprint(w, response)
+			})
+			ctx := context.Background()
+
+			state, diags := res.RefreshWithoutUpgrade(ctx, &terraform.InstanceState{ID: c.id, Attributes: c.defaults}, client)
+			if diags.HasError() {
+				t.Fatalf("unexpected refresh error: %v", diags)
+			}
+
+			// turning the protection on and off only changes the state
+			for _, protected := range []bool{true, false} {
+				config := map[string]interface{}{"deletion_protection": protected}
+				for k, v := range c.config {
+					config[k] = v
+				}
+				diff, err := res.Diff(ctx, state, terraform.NewResourceConfigRaw(config), client)
+				if err != nil {
+					t.Fatalf("unexpected diff error: %s", err)
+				}
+				if diff.RequiresNew() || len(diff.Attributes) != 1 {
+					t.Fatalf("unexpected diff: %v", diff)
+				}
+				state, diags = res.Apply(ctx, state, diff, client)
+				if diags.HasError() {
+					t.Fatalf("unexpected apply error: %v", diags)
+				}
+				if actual := state.Attributes["deletion_protection"]; actual != fmt.Sprint(protected) {
+					t.Errorf("invalid deletion_protection: expected: %t, actual: %s", protected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestDeletionProtection_refusesDeletion(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusBadRequest)
+	}, segment.ClientConfig{})
+
+	cases := []struct {
+		resource string
+		id       string
+		expected string
+	}{
+		{"segment_source", "workspaces/myworkspace/sources/ios", `cannot delete source "ios": deletion protection is enabled`},
+		{"segment_destination", "workspaces/myworkspace/sources/ios/destinations/webhooks", `cannot delete destination "ios/webhooks": deletion protection is enabled`},
+		{"segment_tracking_plan", "rs_123", `cannot delete tracking plan "rs_123": deletion protection is enabled`},
+	}
+
+	for _, c := range cases {
+		res := segment.Provider().ResourcesMap[c.resource]
+		state := &terraform.InstanceState{
+			ID: c.id,
+			Attributes: map[string]string{
+				"id":                  c.id,
+				"slug":                "ios",
+				"deletion_protection": "true",
+			},
+		}
+		_, diags := res.Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, client)
+		if !diags.HasError() || diags[0].Summary != c.expected {
+			t.Errorf("invalid error for %s: expected: %q, actual: %v", c.resource, c.expected, diags)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package segment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/forteilgmbh/segment-config-go/segment"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ErrorKind is the category of an error returned by the Segment API.
+type ErrorKind int
+
+const (
+	ErrorKindUnknown ErrorKind = iota
+	ErrorKindNotFound
+	ErrorKindConflict
+	ErrorKindRateLimited
+	ErrorKindPermissionDenied
+	ErrorKindValidation
+	ErrorKindTransient
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindNotFound:
+		return "not found"
+	case ErrorKindConflict:
+		return "conflict"
+	case ErrorKindRateLimited:
+		return "rate limited"
+	case ErrorKindPermissionDenied:
+		return "permission denied"
+	case ErrorKindValidation:
+		return "invalid request"
+	case ErrorKindTransient:
+		return "temporary failure"
+	default:
+		return "unexpected error"
+	}
+}
+
+// hint tells the user what can be done about an error of the given kind.
+func (k ErrorKind) hint() string {
+	switch k {
+	case ErrorKindNotFound:
+		return "The object does not exist in Segment. If it was deleted outside of Terraform, refresh the state; otherwise check the slugs and IDs in the configuration."
+	case ErrorKindConflict:
+		return "The object already exists or was modified concurrently. Import the existing object into the state or retry once the other change is finished."
+	case ErrorKindRateLimited:
+		return `Segment kept rate limiting the requests after all retries. Lower "requests_per_second" or "max_concurrent_requests", raise "max_retries", or run Terraform with a lower -parallelism.`
+	case ErrorKindPermissionDenied:
+		return "Check that the access token is valid, has not expired and has access to the workspace configured in the provider."
+	case ErrorKindValidation:
+		return "Segment rejected the request. Check the arguments of the resource against the Segment documentation."
+	case ErrorKindTransient:
+		return "The Segment API is temporarily unavailable. Retry the operation later."
+	default:
+		return ""
+	}
+}
+
+// grpc status codes which the Segment API returns in the body of 400 and 500 responses
+const (
+	grpcInvalidArgument    = 3
+	grpcDeadlineExceeded   = 4
+	grpcNotFound           = 5
+	grpcAlreadyExists      = 6
+	grpcPermissionDenied   = 7
+	grpcResourceExhausted  = 8
+	grpcFailedPrecondition = 9
+	grpcAborted            = 10
+	grpcInternal           = 13
+	grpcUnavailable        = 14
+	grpcUnauthenticated    = 16
+)
+
+// ErrorKindOf classifies an error returned by the Client.
+func ErrorKindOf(err error) ErrorKind {
+	if err == nil {
+		return ErrorKindUnknown
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrorKindUnknown
+	}
+
+	var apiErr *segment.SegmentApiError
+	if !errors.As(err, &apiErr) {
+		var netErr net.Error
+		if errors.As(err, &netErr) {
+			return ErrorKindTransient
+		}
+		return ErrorKindUnknown
+	}
+
+	switch apiErr.Code {
+	case http.StatusNotFound, grpcNotFound:
+		return ErrorKindNotFound
+	case http.StatusConflict, grpcAlreadyExists, grpcAborted, grpcFailedPrecondition:
+		return ErrorKindConflict
+	case http.StatusTooManyRequests, grpcResourceExhausted:
+		return ErrorKindRateLimited
+	case http.StatusUnauthorized, http.StatusForbidden, grpcPermissionDenied, grpcUnauthenticated:
+		return ErrorKindPermissionDenied
+	case http.StatusBadRequest, grpcInvalidArgument:
+		if isFilterNotFoundApiErr(apiErr) {
+			return ErrorKindNotFound
+		}
+		return ErrorKindValidation
+	case grpcInternal, grpcDeadlineExceeded, grpcUnavailable:
+		return ErrorKindTransient
+	}
+	if apiErr.Code >= 500 && apiErr.Code < 600 {
+		return ErrorKindTransient
+	}
+	return ErrorKindUnknown
+}
+
+// IsNotFoundErr reports whether err means that the requested object does not exist in Segment.
+func IsNotFoundErr(err error) bool {
+	return ErrorKindOf(err) == ErrorKindNotFound
+}
+
+// isTransientErr reports whether err is temporary, i.e. the same request may succeed later.
+func isTransientErr(err error) bool {
+	switch ErrorKindOf(err) {
+	case ErrorKindTransient, ErrorKindRateLimited:
+		return true
+	default:
+		return false
+	}
+}
+
+func isFilterNotFoundApiErr(err error) bool {
+	// special case for destination filters: Segment API returns 400 instead of 404
+	// and client library further obfuscates this error for some reason
+	return isApiErr(err, grpcInvalidArgument, "filter does not exist")
+}
+
+func Is500ValidatePermissionsErr(err error) bool {
+	// another special case for destination filters: Segment API returns 500 this time instead of 404
+	return isApiErr(err, grpcInternal, "failed to validate permissions due to an internal error")
+}
+
+func Is500NilDereferenceErr(err error) bool {
+	// another special case, for source schema config: Segment API returns 500 with nil dereference error xD
+	return isApiErr(err, grpcInternal, "runtime error: invalid memory address or nil pointer dereference")
+}
+
+// isPermanentApiError reports whether a 500 returned by the Segment API is known to be permanent,
+// i.e. retrying the request is pointless. For filters and schema configs, it actually means that the object is missing,
+// which their resources check with Is500ValidatePermissionsErr and Is500NilDereferenceErr.
+func isPermanentApiError(err error) bool {
+	return Is500ValidatePermissionsErr(err) || Is500NilDereferenceErr(err)
+}
+
+func isApiErr(err error, code int, message string) bool {
+	var apiErr *segment.SegmentApiError
+	return errors.As(err, &apiErr) && apiErr.Code == code && strings.Contains(apiErr.Message, message)
+}
+
+// apiErrorDiag turns an error returned by the Client into a diagnostic which names the failed action,
+// the category of the error and what the user can do about it.
+func apiErrorDiag(err error, format string, a ...interface{}) diag.Diagnostics {
+	kind := ErrorKindOf(err)
+	detail := err.Error()
+	if hint := kind.hint(); hint != "" {
+		detail = fmt.Sprintf("%s\n\n%s", detail, hint)
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("%s: %s", fmt.Sprintf(format, a...), kind),
+		Detail:   detail,
+	}}
+}
+
+// redactDiag replaces the given secret values in the summaries and details of diagnostics,
+// as the Segment API may echo the values of a rejected request back in its errors.
+func redactDiag(diags diag.Diagnostics, secrets []string) diag.Diagnostics {
+	for i := range diags {
+		for _, secret := range secrets {
+			if secret == "" {
+				continue
+			}
+			diags[i].Summary = strings.ReplaceAll(diags[i].Summary, secret, redactedValue)
+			diags[i].Detail = strings.ReplaceAll(diags[i].Detail, secret, redactedValue)
+		}
+	}
+	return diags
+}
+
+const redactedValue = "(sensitive value)"
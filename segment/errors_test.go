@@ -0,0 +1,39 @@
+package segment_test
+
+import (
+	"errors"
+	"fmt"
+	segmentapi "github.com/forteilgmbh/segment-config-go/segment"
+	"github.com/forteilgmbh/terraform-provider-segment/segment"
+	"testing"
+)
+
+func TestErrorKindOf(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected segment.ErrorKind
+	}{
+		{nil, segment.ErrorKindUnknown},
+		{errors.New("boom"), segment.ErrorKindUnknown},
+		{&segmentapi.SegmentApiError{Code: 404, Message: "the requested uri does not exist"}, segment.ErrorKindNotFound},
+		{&segmentapi.SegmentApiError{Code: 5, Message: "not found"}, segment.ErrorKindNotFound},
+		{&segmentapi.SegmentApiError{Code: 3, Message: "filter does not exist"}, segment.ErrorKindNotFound},
+		// these 500s mean that the object is missing for filters and schema configs only, which check them themselves
+		{&segmentapi.SegmentApiError{Code: 13, Message: "failed to validate permissions due to an internal error"}, segment.ErrorKindTransient},
+		{&segmentapi.SegmentApiError{Code: 13, Message: "runtime error: invalid memory address or nil pointer dereference"}, segment.ErrorKindTransient},
+		{&segmentapi.SegmentApiError{Code: 6, Message: "source already exists"}, segment.ErrorKindConflict},
+		{&segmentapi.SegmentApiError{Code: 429, Message: "too many requests to API"}, segment.ErrorKindRateLimited},
+		{&segmentapi.SegmentApiError{Code: 401, Message: "invalid access token"}, segment.ErrorKindPermissionDenied},
+		{&segmentapi.SegmentApiError{Code: 7, Message: "permission denied"}, segment.ErrorKindPermissionDenied},
+		{&segmentapi.SegmentApiError{Code: 3, Message: "invalid catalog name"}, segment.ErrorKindValidation},
+		{&segmentapi.SegmentApiError{Code: 13, Message: "internal error"}, segment.ErrorKindTransient},
+		{&segmentapi.SegmentApiError{Code: 503, Message: "bad response code"}, segment.ErrorKindTransient},
+		{fmt.Errorf("cannot list tracking plans: %w", &segmentapi.SegmentApiError{Code: 404}), segment.ErrorKindNotFound},
+	}
+
+	for _, c := range cases {
+		if actual := segment.ErrorKindOf(c.err); actual != c.expected {
+			t.Errorf("invalid kind of %v: expected: %q, actual: %q", c.err, c.expected, actual)
+		}
+	}
+}
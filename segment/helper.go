@@ -2,56 +2,11 @@ package segment
 
 import (
 	"encoding/json"
-	"github.com/forteilgmbh/segment-config-go/segment"
 	"reflect"
+	"sort"
 	"strings"
 )
 
-func IsNotFoundErr(err error) bool {
-	if err == nil {
-		return false
-	}
-	apiErr, ok := err.(*segment.SegmentApiError)
-	if !ok {
-		return false
-	}
-	// special case for destination filters: Segment API returns 400 instead of 404
-	// and client library further obfuscates this error for some reason
-	if apiErr.Code == 3 && strings.Contains(apiErr.Message, "filter does not exist") {
-		return true
-	}
-	if apiErr.Code != 404 {
-		return false
-	}
-	return true
-}
-
-func Is500ValidatePermissionsErr(err error) bool {
-	// another special case for destination filters: Segment API returns 500 this time instead of 404
-	if err == nil {
-		return false
-	}
-	if apiErr, ok := err.(*segment.SegmentApiError); ok {
-		if apiErr.Code == 13 && strings.Contains(apiErr.Message, "failed to validate permissions due to an internal error") {
-			return true
-		}
-	}
-	return false
-}
-
-func Is500NilDereferenceErr(err error) bool {
-	// another special case, for source schema config: Segment API returns 500 with nil dereference error xD
-	if err == nil {
-		return false
-	}
-	if apiErr, ok := err.(*segment.SegmentApiError); ok {
-		if apiErr.Code == 13 && strings.Contains(apiErr.Message, "runtime error: invalid memory address or nil pointer dereference") {
-			return true
-		}
-	}
-	return false
-}
-
 func IsNilOrZeroValue(v interface{}) bool {
 	return v == nil || reflect.DeepEqual(v, reflect.Zero(reflect.TypeOf(v)).Interface())
 }
@@ -113,3 +68,59 @@ func Contains(x string, l []string) bool {
 	}
 	return false
 }
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func minInt(v int, vs ...int) int {
+	for _, x := range vs {
+		if x < v {
+			v = x
+		}
+	}
+	return v
+}
+
+// closestMatches returns at most n candidates most similar to s, the closest first.
+// Candidates which differ from s by more than half of its length are not considered similar.
+func closestMatches(s string, candidates []string, n int) []string {
+	type match struct {
+		candidate string
+		distance  int
+	}
+	matches := make([]match, 0)
+	for _, c := range candidates {
+		d := levenshtein(strings.ToLower(s), strings.ToLower(c))
+		if d <= len(s)/2 || strings.Contains(strings.ToLower(c), strings.ToLower(s)) {
+			matches = append(matches, match{c, d})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+
+	result := make([]string, 0, n)
+	for i := 0; i < len(matches) && i < n; i++ {
+		result = append(result, matches[i].candidate)
+	}
+	return result
+}
@@ -0,0 +1,61 @@
+package segment
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"testing"
+)
+
+func TestLevenshtein(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"abc", "abc", 0},
+		{"ios", "io", 1},
+		{"javascript", "javascrpt", 1},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"über", "uber", 1},
+	}
+
+	for _, c := range cases {
+		if actual := levenshtein(c.a, c.b); actual != c.expected {
+			t.Errorf("invalid distance between %q and %q: expected: %d, actual: %d", c.a, c.b, c.expected, actual)
+		}
+		if actual := levenshtein(c.b, c.a); actual != c.expected {
+			t.Errorf("invalid distance between %q and %q: expected: %d, actual: %d", c.b, c.a, c.expected, actual)
+		}
+	}
+}
+
+func TestClosestMatches(t *testing.T) {
+	candidates := []string{"ios", "android", "javascript", "net", "node", "http-api", "javascript-server"}
+	cases := []struct {
+		s        string
+		n        int
+		expected []string
+	}{
+		{"ios", 3, []string{"ios"}},
+		{"io", 3, []string{"ios"}},
+		// a transposition counts as two edits
+		{"iso", 3, []string{}},
+		{"javascrpt", 3, []string{"javascript"}},
+		{"JavaScript", 3, []string{"javascript", "javascript-server"}},
+		{"nodes", 3, []string{"node"}},
+		{"ne", 3, []string{"net"}},
+		{"nod", 1, []string{"node"}},
+		{"ruby", 3, []string{}},
+		// every candidate contains the empty string, so the shortest ones are the closest
+		{"", 3, []string{"ios", "net", "node"}},
+	}
+
+	for _, c := range cases {
+		actual := closestMatches(c.s, candidates, c.n)
+		if !cmp.Equal(actual, c.expected) {
+			t.Errorf("invalid matches of %q: %s", c.s, cmp.Diff(c.expected, actual))
+		}
+	}
+}
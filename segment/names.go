@@ -0,0 +1,252 @@
+package segment
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Segment identifies its objects by names made of collection/ID pairs,
+// e.g. "workspaces/myworkspace/sources/ios/destinations/webhooks".
+// The functions below build and validate those names, so that a malformed ID (e.g. passed to "terraform import")
+// results in an error instead of a panic.
+
+const (
+	sourceNameFormat            = "workspaces/<workspace>/sources/<source-slug>"
+	sourceSchemaConfigFormat    = "workspaces/<workspace>/sources/<source-slug>/schema-config"
+	destinationNameFormat       = "workspaces/<workspace>/sources/<source-slug>/destinations/<destination-slug>"
+	destinationFilterNameFormat = "workspaces/<workspace>/sources/<source-slug>/destinations/<destination-slug>/[config/<config-id>/]filters/<filter-id>"
+	trackingPlanNameFormat      = "workspaces/<workspace>/tracking-plans/<tracking-plan-id>"
+	trackingPlanConnectionIdFmt = "<tracking-plan-id>|<source-slug>"
+)
+
+// parseName splits name into the IDs following the given collections and checks that the name
+// belongs to workspace. The first collection is always "workspaces" and is not passed explicitly.
+func parseName(workspace, name, format string, collections ...string) ([]string, error) {
+	segments := strings.Split(name, "/")
+	collections = append([]string{"workspaces"}, collections...)
+
+	if len(segments) != 2*len(collections) {
+		return nil, fmt.Errorf("invalid name %q: expected format %q", name, format)
+	}
+	ids := make([]string, 0, len(collections))
+	for i, collection := range collections {
+		id := segments[2*i+1]
+		if segments[2*i] != collection || id == "" || strings.TrimSpace(id) != id {
+			return nil, fmt.Errorf("invalid name %q: expected format %q", name, format)
+		}
+		ids = append(ids, id)
+	}
+	if err := checkWorkspace(workspace, name, ids[0]); err != nil {
+		return nil, err
+	}
+	return ids[1:], nil
+}
+
+func checkWorkspace(expected, name, actual string) error {
+	if expected != "" && actual != expected {
+		return fmt.Errorf("%q belongs to workspace %q, but the provider manages workspace %q", name, actual, expected)
+	}
+	return nil
+}
+
+func SourceSlugToName(workspace, slug string) string {
+	return fmt.Sprintf("workspaces/%s/sources/%s", workspace, slug)
+}
+
+// SourceNameToSlug returns the slug of the source with the given name,
+// e.g. "ios" for "workspaces/myworkspace/sources/ios"
+func SourceNameToSlug(workspace, name string) (string, error) {
+	ids, err := parseName(workspace, name, sourceNameFormat, "sources")
+	if err != nil {
+		return "", err
+	}
+	return ids[0], nil
+}
+
+func SourceSchemaConfigSlugToName(workspace, srcSlug string) string {
+	return fmt.Sprintf("%s/schema-config", SourceSlugToName(workspace, srcSlug))
+}
+
+// SourceSchemaConfigNameToSourceSlug returns the slug of the source of the schema config with the given name,
+// e.g. "ios" for "workspaces/myworkspace/sources/ios/schema-config"
+func SourceSchemaConfigNameToSourceSlug(workspace, name string) (string, error) {
+	if !strings.HasSuffix(name, "/schema-config") {
+		return "", fmt.Errorf("invalid name %q: expected format %q", name, sourceSchemaConfigFormat)
+	}
+	ids, err := parseName(workspace, strings.TrimSuffix(name, "/schema-config"), sourceSchemaConfigFormat, "sources")
+	if err != nil {
+		return "", err
+	}
+	return ids[0], nil
+}
+
+func DestinationSlugToName(workspace, srcSlug, slug string) string {
+	return fmt.Sprintf("%s/destinations/%s", SourceSlugToName(workspace, srcSlug), slug)
+}
+
+// DestinationNameToSlugs returns the slugs of the source and of the destination with the given name,
+// e.g. "ios" and "webhooks" for "workspaces/myworkspace/sources/ios/destinations/webhooks"
+func DestinationNameToSlugs(workspace, name string) (srcSlug, slug string, err error) {
+	ids, err := parseName(workspace, name, destinationNameFormat, "sources", "destinations")
+	if err != nil {
+		return "", "", err
+	}
+	return ids[0], ids[1], nil
+}
+
+// DestinationConfigKeyToName returns the full name of the config with the given key of the destination destName,
+// e.g. "workspaces/myworkspace/sources/ios/destinations/webhooks/config/sharedSecret" for "sharedSecret".
+// A key which is already a full name is returned unchanged.
+func DestinationConfigKeyToName(destName, key string) string {
+	if strings.Contains(key, "/") {
+		return key
+	}
+	return fmt.Sprintf("%s/config/%s", destName, key)
+}
+
+// DestinationConfigNameToKey returns the key of the config with the given full name,
+// e.g. "sharedSecret" for "workspaces/myworkspace/sources/ios/destinations/webhooks/config/sharedSecret"
+func DestinationConfigNameToKey(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+// DestinationFilterNameToIds returns the slugs of the source and of the destination, and the ID of the filter
+// with the given name, e.g. "ios", "webhooks" and "df_123"
+// for "workspaces/myworkspace/sources/ios/destinations/webhooks/config/abc/filters/df_123"
+func DestinationFilterNameToIds(workspace, name string) (srcSlug, dstSlug, id string, err error) {
+	// the config segment is returned by the API, but it is optional when the name is written by hand
+	if strings.Contains(name, "/config/") {
+		ids, err := parseName(workspace, name, destinationFilterNameFormat, "sources", "destinations", "config", "filters")
+		if err != nil {
+			return "", "", "", err
+		}
+		return ids[0], ids[1], ids[3], nil
+	}
+	ids, err := parseName(workspace, name, destinationFilterNameFormat, "sources", "destinations", "filters")
+	if err != nil {
+		return "", "", "", err
+	}
+	return ids[0], ids[1], ids[2], nil
+}
+
+// TrackingPlanNameToId returns the ID of the tracking plan with the given name,
+// e.g. "rs_123" for "workspaces/myworkspace/tracking-plans/rs_123"
+func TrackingPlanNameToId(workspace, name string) (string, error) {
+	ids, err := parseName(workspace, name, trackingPlanNameFormat, "tracking-plans")
+	if err != nil {
+		return "", err
+	}
+	return ids[0], nil
+}
+
+func createTrackingPlanSourceConnectionId(planId, srcSlug string) string {
+	return fmt.Sprintf("%s|%s", planId, srcSlug)
+}
+
+// SplitTrackingPlanSourceConnectionId returns the tracking plan ID and the source slug
+// of a segment_tracking_plan_source_connection ID, e.g. "rs_123" and "ios" for "rs_123|ios"
+func SplitTrackingPlanSourceConnectionId(id string) (planId, srcSlug string, err error) {
+	s := strings.Split(id, "|")
+	if len(s) != 2 || s[0] == "" || s[1] == "" {
+		return "", "", fmt.Errorf("invalid ID %q: expected format %q", id, trackingPlanConnectionIdFmt)
+	}
+	return s[0], s[1], nil
+}
+
+// The functions below parse IDs passed to "terraform import". Besides the canonical IDs,
+// they accept the short forms, i.e. the slugs and IDs separated by "/" without the collections.
+
+// ParseSourceImportId returns the slug of the source identified by "<source-slug>"
+// or "workspaces/<workspace>/sources/<source-slug>"
+func ParseSourceImportId(workspace, id string) (string, error) {
+	if isFullName(id) {
+		return SourceNameToSlug(workspace, id)
+	}
+	ids, err := parseShortId(id, "<source-slug>", 1)
+	if err != nil {
+		return "", err
+	}
+	return ids[0], nil
+}
+
+// ParseSourceSchemaConfigImportId returns the slug of the source of the schema config identified by "<source-slug>",
+// "workspaces/<workspace>/sources/<source-slug>" or "workspaces/<workspace>/sources/<source-slug>/schema-config"
+func ParseSourceSchemaConfigImportId(workspace, id string) (string, error) {
+	if isFullName(id) && strings.HasSuffix(id, "/schema-config") {
+		return SourceSchemaConfigNameToSourceSlug(workspace, id)
+	}
+	return ParseSourceImportId(workspace, id)
+}
+
+// ParseDestinationImportId returns the slugs of the source and of the destination identified by
+// "<source-slug>/<destination-slug>" or "workspaces/<workspace>/sources/<source-slug>/destinations/<destination-slug>"
+func ParseDestinationImportId(workspace, id string) (srcSlug, slug string, err error) {
+	if isFullName(id) {
+		return DestinationNameToSlugs(workspace, id)
+	}
+	ids, err := parseShortId(id, "<source-slug>/<destination-slug>", 2)
+	if err != nil {
+		return "", "", err
+	}
+	return ids[0], ids[1], nil
+}
+
+// ParseDestinationFilterImportId returns the slugs of the source and of the destination, and the ID of the filter
+// identified by "<source-slug>/<destination-slug>/<filter-id>" or by the full name of the filter
+func ParseDestinationFilterImportId(workspace, id string) (srcSlug, dstSlug, filterId string, err error) {
+	if isFullName(id) {
+		return DestinationFilterNameToIds(workspace, id)
+	}
+	ids, err := parseShortId(id, "<source-slug>/<destination-slug>/<filter-id>", 3)
+	if err != nil {
+		return "", "", "", err
+	}
+	return ids[0], ids[1], ids[2], nil
+}
+
+// ParseTrackingPlanImportId returns the ID of the tracking plan identified by "<tracking-plan-id>"
+// or "workspaces/<workspace>/tracking-plans/<tracking-plan-id>"
+func ParseTrackingPlanImportId(workspace, id string) (string, error) {
+	if isFullName(id) {
+		return TrackingPlanNameToId(workspace, id)
+	}
+	ids, err := parseShortId(id, "<tracking-plan-id>", 1)
+	if err != nil {
+		return "", err
+	}
+	return ids[0], nil
+}
+
+// ParseTrackingPlanSourceConnectionImportId returns the tracking plan ID and the source slug of the connection
+// identified by "<tracking-plan>|<source>", where both parts may be given in any of the forms accepted
+// by ParseTrackingPlanImportId and ParseSourceImportId, e.g. "rs_123|ios" or "rs_123|workspaces/myworkspace/sources/ios"
+func ParseTrackingPlanSourceConnectionImportId(workspace, id string) (planId, srcSlug string, err error) {
+	s := strings.Split(id, "|")
+	if len(s) != 2 {
+		return "", "", fmt.Errorf("invalid ID %q: expected format %q", id, trackingPlanConnectionIdFmt)
+	}
+	if planId, err = ParseTrackingPlanImportId(workspace, s[0]); err != nil {
+		return "", "", err
+	}
+	if srcSlug, err = ParseSourceImportId(workspace, s[1]); err != nil {
+		return "", "", err
+	}
+	return planId, srcSlug, nil
+}
+
+func isFullName(id string) bool {
+	return strings.HasPrefix(id, "workspaces/")
+}
+
+func parseShortId(id, format string, n int) ([]string, error) {
+	ids := strings.Split(id, "/")
+	if len(ids) != n {
+		return nil, fmt.Errorf("invalid ID %q: expected format %q", id, format)
+	}
+	for _, s := range ids {
+		if s == "" || strings.TrimSpace(s) != s || strings.Contains(s, "|") {
+			return nil, fmt.Errorf("invalid ID %q: expected format %q", id, format)
+		}
+	}
+	return ids, nil
+}
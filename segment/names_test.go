@@ -0,0 +1,196 @@
+package segment_test
+
+import (
+	"github.com/forteilgmbh/terraform-provider-segment/segment"
+	"testing"
+)
+
+func TestSourceNameToSlug(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected string
+		valid    bool
+	}{
+		{"workspaces/myworkspace/sources/ios", "ios", true},
+		{"workspaces/otherworkspace/sources/ios", "", false},
+		{"workspaces/myworkspace/sources/", "", false},
+		{"workspaces/myworkspace/sources", "", false},
+		{"workspaces/myworkspace/destinations/ios", "", false},
+		{"workspaces/myworkspace/sources/ios/destinations/webhooks", "", false},
+		{"ios", "", false},
+		{"", "", false},
+	}
+
+	for _, c := range cases {
+		actual, err := segment.SourceNameToSlug("myworkspace", c.name)
+		if c.valid != (err == nil) {
+			t.Errorf("invalid result for %q: expected valid: %t, got error: %v", c.name, c.valid, err)
+		}
+		if actual != c.expected {
+			t.Errorf("invalid slug for %q: expected: %q, actual: %q", c.name, c.expected, actual)
+		}
+	}
+}
+
+func TestSourceSchemaConfigNameToSourceSlug(t *testing.T) {
+	slug, err := segment.SourceSchemaConfigNameToSourceSlug("myworkspace", "workspaces/myworkspace/sources/ios/schema-config")
+	if err != nil || slug != "ios" {
+		t.Errorf("unexpected result: %q, %v", slug, err)
+	}
+	if _, err := segment.SourceSchemaConfigNameToSourceSlug("myworkspace", "workspaces/myworkspace/sources/ios"); err == nil {
+		t.Error("expected error for source name without schema-config suffix")
+	}
+}
+
+func TestDestinationNameToSlugs(t *testing.T) {
+	srcSlug, slug, err := segment.DestinationNameToSlugs("myworkspace", "workspaces/myworkspace/sources/ios/destinations/webhooks")
+	if err != nil || srcSlug != "ios" || slug != "webhooks" {
+		t.Errorf("unexpected result: %q, %q, %v", srcSlug, slug, err)
+	}
+
+	for _, name := range []string{
+		"workspaces/myworkspace/sources/ios",
+		"workspaces/myworkspace/sources/ios/destinations/",
+		"workspaces/otherworkspace/sources/ios/destinations/webhooks",
+		"workspaces/myworkspace/sources/ios/filters/webhooks",
+	} {
+		if _, _, err := segment.DestinationNameToSlugs("myworkspace", name); err == nil {
+			t.Errorf("expected error for %q", name)
+		}
+	}
+}
+
+func TestDestinationConfigNames(t *testing.T) {
+	destName := "workspaces/myworkspace/sources/ios/destinations/webhooks"
+	fullName := destName + "/config/sharedSecret"
+
+	for _, key := range []string{"sharedSecret", fullName} {
+		if name := segment.DestinationConfigKeyToName(destName, key); name != fullName {
+			t.Errorf("unexpected name for %q: %q", key, name)
+		}
+	}
+	if key := segment.DestinationConfigNameToKey(fullName); key != "sharedSecret" {
+		t.Errorf("unexpected key: %q", key)
+	}
+	if key := segment.DestinationConfigNameToKey("sharedSecret"); key != "sharedSecret" {
+		t.Errorf("unexpected key of a key: %q", key)
+	}
+}
+
+func TestDestinationFilterNameToIds(t *testing.T) {
+	for _, name := range []string{
+		"workspaces/myworkspace/sources/ios/destinations/webhooks/config/abc123/filters/df_123",
+		"workspaces/myworkspace/sources/ios/destinations/webhooks/filters/df_123",
+	} {
+		srcSlug, dstSlug, id, err := segment.DestinationFilterNameToIds("myworkspace", name)
+		if err != nil || srcSlug != "ios" || dstSlug != "webhooks" || id != "df_123" {
+			t.Errorf("unexpected result for %q: %q, %q, %q, %v", name, srcSlug, dstSlug, id, err)
+		}
+	}
+
+	for _, name := range []string{
+		"workspaces/myworkspace/sources/ios/destinations/webhooks",
+		"workspaces/myworkspace/sources/ios/destinations/webhooks/config/abc123/filters",
+		"workspaces/otherworkspace/sources/ios/destinations/webhooks/filters/df_123",
+		"df_123",
+	} {
+		if _, _, _, err := segment.DestinationFilterNameToIds("myworkspace", name); err == nil {
+			t.Errorf("expected error for %q", name)
+		}
+	}
+}
+
+func TestTrackingPlanNameToId(t *testing.T) {
+	id, err := segment.TrackingPlanNameToId("myworkspace", "workspaces/myworkspace/tracking-plans/rs_123")
+	if err != nil || id != "rs_123" {
+		t.Errorf("unexpected result: %q, %v", id, err)
+	}
+	if _, err := segment.TrackingPlanNameToId("myworkspace", "workspaces/otherworkspace/tracking-plans/rs_123"); err == nil {
+		t.Error("expected error for tracking plan of another workspace")
+	}
+}
+
+func TestSplitTrackingPlanSourceConnectionId(t *testing.T) {
+	planId, srcSlug, err := segment.SplitTrackingPlanSourceConnectionId("rs_123|ios")
+	if err != nil || planId != "rs_123" || srcSlug != "ios" {
+		t.Errorf("unexpected result: %q, %q, %v", planId, srcSlug, err)
+	}
+
+	for _, id := range []string{"rs_123", "rs_123|", "|ios", "rs_123|ios|android", ""} {
+		if _, _, err := segment.SplitTrackingPlanSourceConnectionId(id); err == nil {
+			t.Errorf("expected error for %q", id)
+		}
+	}
+}
+
+func TestParseImportIds(t *testing.T) {
+	srcSlug, err := segment.ParseSourceImportId("myworkspace", "ios")
+	if err != nil || srcSlug != "ios" {
+		t.Errorf("unexpected result: %q, %v", srcSlug, err)
+	}
+	srcSlug, err = segment.ParseSourceImportId("myworkspace", "workspaces/myworkspace/sources/ios")
+	if err != nil || srcSlug != "ios" {
+		t.Errorf("unexpected result: %q, %v", srcSlug, err)
+	}
+
+	for _, id := range []string{"ios", "workspaces/myworkspace/sources/ios", "workspaces/myworkspace/sources/ios/schema-config"} {
+		srcSlug, err := segment.ParseSourceSchemaConfigImportId("myworkspace", id)
+		if err != nil || srcSlug != "ios" {
+			t.Errorf("unexpected result for %q: %q, %v", id, srcSlug, err)
+		}
+	}
+
+	for _, id := range []string{"ios/webhooks", "workspaces/myworkspace/sources/ios/destinations/webhooks"} {
+		srcSlug, slug, err := segment.ParseDestinationImportId("myworkspace", id)
+		if err != nil || srcSlug != "ios" || slug != "webhooks" {
+			t.Errorf("unexpected result for %q: %q, %q, %v", id, srcSlug, slug, err)
+		}
+	}
+
+	for _, id := range []string{"ios/webhooks/df_123", "workspaces/myworkspace/sources/ios/destinations/webhooks/config/abc123/filters/df_123"} {
+		srcSlug, dstSlug, filterId, err := segment.ParseDestinationFilterImportId("myworkspace", id)
+		if err != nil || srcSlug != "ios" || dstSlug != "webhooks" || filterId != "df_123" {
+			t.Errorf("unexpected result for %q: %q, %q, %q, %v", id, srcSlug, dstSlug, filterId, err)
+		}
+	}
+
+	for _, id := range []string{"rs_123", "workspaces/myworkspace/tracking-plans/rs_123"} {
+		planId, err := segment.ParseTrackingPlanImportId("myworkspace", id)
+		if err != nil || planId != "rs_123" {
+			t.Errorf("unexpected result for %q: %q, %v", id, planId, err)
+		}
+	}
+
+	for _, id := range []string{"rs_123|ios", "rs_123|workspaces/myworkspace/sources/ios", "workspaces/myworkspace/tracking-plans/rs_123|ios"} {
+		planId, srcSlug, err := segment.ParseTrackingPlanSourceConnectionImportId("myworkspace", id)
+		if err != nil || planId != "rs_123" || srcSlug != "ios" {
+			t.Errorf("unexpected result for %q: %q, %q, %v", id, planId, srcSlug, err)
+		}
+	}
+}
+
+func TestParseImportIds_invalid(t *testing.T) {
+	if _, err := segment.ParseSourceImportId("myworkspace", "ios/webhooks"); err == nil {
+		t.Error("expected error for source ID with destination")
+	}
+	if _, err := segment.ParseSourceImportId("myworkspace", "workspaces/otherworkspace/sources/ios"); err == nil {
+		t.Error("expected error for source of another workspace")
+	}
+	if _, _, err := segment.ParseDestinationImportId("myworkspace", "ios"); err == nil {
+		t.Error("expected error for destination ID without destination slug")
+	}
+	if _, _, err := segment.ParseDestinationImportId("myworkspace", "ios/"); err == nil {
+		t.Error("expected error for destination ID with empty destination slug")
+	}
+	if _, _, _, err := segment.ParseDestinationFilterImportId("myworkspace", "ios/webhooks"); err == nil {
+		t.Error("expected error for filter ID without filter ID")
+	}
+	if _, err := segment.ParseTrackingPlanImportId("myworkspace", "workspaces/otherworkspace/tracking-plans/rs_123"); err == nil {
+		t.Error("expected error for tracking plan of another workspace")
+	}
+	for _, id := range []string{"rs_123", "rs_123|", "rs_123|ios|android", "rs_123|workspaces/otherworkspace/sources/ios"} {
+		if _, _, err := segment.ParseTrackingPlanSourceConnectionImportId("myworkspace", id); err == nil {
+			t.Errorf("expected error for %q", id)
+		}
+	}
+}
@@ -1,10 +1,14 @@
 package segment
 
 import (
-	"github.com/forteilgmbh/segment-config-go/segment"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"time"
 )
 
+// defaultTimeout applies to every operation of a resource, unless overridden in its "timeouts" block.
+const defaultTimeout = 5 * time.Minute
+
 func Provider() *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
@@ -20,6 +24,48 @@ func Provider() *schema.Provider {
 				Required:    true,
 				DefaultFunc: schema.EnvDefaultFunc("SEGMENT_WORKSPACE", nil),
 			},
+			"region": {
+				Type:         schema.TypeString,
+				Description:  `The Segment region hosting the workspace ("eu" or "us")`,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SEGMENT_REGION", DefaultRegion),
+				ValidateFunc: validation.StringInSlice(regionNames(), false),
+			},
+			"endpoint": {
+				Type:         schema.TypeString,
+				Description:  `Base URL of the Segment Config API (e.g. "http://localhost:8080"); takes precedence over "region"`,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SEGMENT_ENDPOINT", nil),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of retries of a request which was rate limited or failed with a transient server error",
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SEGMENT_MAX_RETRIES", DefaultMaxRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of seconds to wait between two retries, also when the Segment API asks for a longer wait through Retry-After",
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SEGMENT_RETRY_MAX_WAIT", int(DefaultRetryMaxWait.Seconds())),
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"requests_per_second": {
+				Type:         schema.TypeFloat,
+				Description:  "Maximum number of requests per second sent to the Segment API, 0 means unlimited",
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SEGMENT_REQUESTS_PER_SECOND", 0.0),
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of concurrent requests sent to the Segment API, 0 means unlimited",
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SEGMENT_MAX_CONCURRENT_REQUESTS", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"segment_source":                          resourceSegmentSource(),
@@ -29,13 +75,48 @@ func Provider() *schema.Provider {
 			"segment_tracking_plan":                   resourceSegmentTrackingPlan(),
 			"segment_tracking_plan_source_connection": resourceSegmentTrackingPlanSourceConnection(),
 		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"segment_source":               dataSourceSegmentSource(),
+			"segment_sources":              dataSourceSegmentSources(),
+			"segment_destination":          dataSourceSegmentDestination(),
+			"segment_destinations":         dataSourceSegmentDestinations(),
+			"segment_tracking_plan":        dataSourceSegmentTrackingPlan(),
+			"segment_tracking_plans":       dataSourceSegmentTrackingPlans(),
+			"segment_catalog_source":       dataSourceSegmentCatalogSource(),
+			"segment_catalog_sources":      dataSourceSegmentCatalogSources(),
+			"segment_catalog_destination":  dataSourceSegmentCatalogDestination(),
+			"segment_catalog_destinations": dataSourceSegmentCatalogDestinations(),
+			"segment_workspace":            dataSourceSegmentWorkspace(),
+		},
 		ConfigureFunc: configureFunc(),
 	}
 }
 
 func configureFunc() func(*schema.ResourceData) (interface{}, error) {
 	return func(d *schema.ResourceData) (interface{}, error) {
-		client := segment.NewClient(d.Get("access_token").(string), d.Get("workspace").(string))
+		baseURL := Regions[d.Get("region").(string)]
+		if endpoint, ok := d.GetOk("endpoint"); ok {
+			baseURL = endpoint.(string)
+		}
+
+		client := NewClient(ClientConfig{
+			AccessToken:  d.Get("access_token").(string),
+			Workspace:    d.Get("workspace").(string),
+			BaseURL:      baseURL,
+			MaxRetries:   d.Get("max_retries").(int),
+			RetryMaxWait: time.Duration(d.Get("retry_max_wait").(int)) * time.Second,
+
+			RequestsPerSecond:     d.Get("requests_per_second").(float64),
+			MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+		})
 		return client, nil
 	}
 }
+
+func regionNames() []string {
+	names := make([]string, 0, len(Regions))
+	for r := range Regions {
+		names = append(names, r)
+	}
+	return names
+}
@@ -1,8 +1,12 @@
 package segment_test
 
 import (
+	"context"
 	"github.com/forteilgmbh/terraform-provider-segment/segment"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -14,6 +18,34 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestProvider_baseURL(t *testing.T) {
+	if os.Getenv("SEGMENT_ENDPOINT") != "" {
+		t.Skip("SEGMENT_ENDPOINT takes precedence over the region")
+	}
+	cases := []struct {
+		config   map[string]interface{}
+		expected string
+	}{
+		{map[string]interface{}{"region": "eu"}, "https://eu1.api.segmentapis.com"},
+		{map[string]interface{}{"region": "us"}, "https://platform.segmentapis.com"},
+		{map[string]interface{}{"region": "us", "endpoint": "http://localhost:8080/"}, "http://localhost:8080"},
+	}
+
+	for _, c := range cases {
+		raw := map[string]interface{}{"access_token": "token", "workspace": "myworkspace"}
+		for k, v := range c.config {
+			raw[k] = v
+		}
+		p := segment.Provider()
+		if diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(raw)); diags.HasError() {
+			t.Fatalf("unexpected error for %v: %v", c.config, diags)
+		}
+		if actual := p.Meta().(*segment.Client).BaseURL(); actual != c.expected {
+			t.Errorf("invalid base URL for %v: expected: %q, actual: %q", c.config, c.expected, actual)
+		}
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("SEGMENT_WORKSPACE"); v == "" {
 		t.Fatal("SEGMENT_WORKSPACE must be set for acceptance tests")
@@ -42,3 +74,19 @@ func configCompose(config ...string) string {
 
 	return str.String()
 }
+
+// newTestClient returns a client of the workspace "myworkspace", otherwise configured by config,
+// whose requests are answered by handler. The server is closed at the end of the test.
+func newTestClient(t *testing.T, handler http.HandlerFunc, config segment.ClientConfig) *segment.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config.Workspace = "myworkspace"
+	config.BaseURL = server.URL
+	return segment.NewClient(config)
+}
+
+// newTestResource returns the resource with the given name and a client whose requests are answered by handler
+func newTestResource(t *testing.T, name string, handler http.HandlerFunc) (*schema.Resource, *segment.Client) {
+	return segment.Provider().ResourcesMap[name], newTestClient(t, handler, segment.ClientConfig{})
+}
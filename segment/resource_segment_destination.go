@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"github.com/forteilgmbh/segment-config-go/segment"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
 	"strings"
 )
 
@@ -43,12 +46,24 @@ func resourceSegmentDestination() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
-							Type:     schema.TypeString,
-							Required: true,
+							Description: `Key of the config (e.g. "trackingId") or its full name (e.g. "workspaces/myworkspace/sources/ios/destinations/google-analytics/config/trackingId")`,
+							Type:        schema.TypeString,
+							Required:    true,
 						},
 						"value": {
-							Type:     schema.TypeString,
-							Required: true,
+							Description:      "Value of the config, JSON objects and arrays are compared semantically",
+							Type:             schema.TypeString,
+							Optional:         true,
+							StateFunc:        normalizedDestinationConfigValue,
+							DiffSuppressFunc: suppressEquivalentDestinationConfigValue,
+						},
+						"sensitive_value": {
+							Description:      "Value of the config to be used instead of `value` for secrets, e.g. API keys, so that it is redacted in plans",
+							Type:             schema.TypeString,
+							Optional:         true,
+							Sensitive:        true,
+							StateFunc:        normalizedDestinationConfigValue,
+							DiffSuppressFunc: suppressEquivalentDestinationConfigValue,
 						},
 						"type": {
 							Type:     schema.TypeString,
@@ -56,51 +71,88 @@ func resourceSegmentDestination() *schema.Resource {
 						},
 					},
 				},
-				Required: true,
+				Set:      destinationConfigHash,
+				Optional: true,
+			},
+			"settings": {
+				Description: "Configs of the destination as JSON-encoded object of option keys to values, " +
+					"an alternative to `configs` where the types are taken from the destination catalog",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+				StateFunc:    normalizedJson,
 			},
+			"authoritative_configs": {
+				Description: "Whether all the configs of the destination are managed by Terraform, the default. " +
+					"Otherwise, only the configs declared in `configs` and `settings` are managed and the other ones are left untouched, " +
+					"including the ones removed from `configs` and `settings`, which are no longer managed but kept in the destination",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"deletion_protection": deletionProtectionSchema("destination"),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
 		},
 		CreateContext: resourceSegmentDestinationCreate,
 		ReadContext:   resourceSegmentDestinationRead,
 		UpdateContext: resourceSegmentDestinationUpdate,
 		DeleteContext: resourceSegmentDestinationDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceSegmentDestinationImport,
 		},
+		CustomizeDiff: customdiff.Sequence(
+			customizeDiffValidateDestinationConfigs,
+			customizeDiffValidateDestinationSettings,
+		),
 	}
 }
 
 func resourceSegmentDestinationCreate(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*segment.Client)
+	client := meta.(*Client)
 
 	slug := r.Get("slug").(string)
 	srcSlug := r.Get("source_slug").(string)
 	connMode := r.Get("connection_mode").(string)
 	enabled := r.Get("enabled").(bool)
-	configs := r.Get("configs").(*schema.Set)
 
-	dest, err := client.CreateDestination(srcSlug, slug, connMode, enabled, extractDestinationConfigs(configs))
+	name := DestinationSlugToName(client.Workspace, srcSlug, slug)
+	dcs, err := extractAllDestinationConfigs(c, client, slug, name, r)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	dest, err := client.CreateDestination(c, srcSlug, slug, connMode, enabled, dcs)
+	if err != nil {
+		diags := apiErrorDiag(err, "cannot create destination %q of source %q", slug, srcSlug)
+		return redactDiag(diags, sensitiveDestinationConfigValues(c, client, slug, r))
+	}
+
 	r.SetId(dest.Name)
 
-	return resourceSegmentDestinationRead(c, r, meta)
+	return append(secretDestinationConfigValueWarnings(c, client, slug, r), resourceSegmentDestinationRead(c, r, meta)...)
 }
 
 func resourceSegmentDestinationRead(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*segment.Client)
+	client := meta.(*Client)
 
-	slug := DestinationNameToSlug(r.Id())
-	srcSlug := DestinationNameToSourceSlug(r.Id())
+	srcSlug, slug, err := DestinationNameToSlugs(client.Workspace, r.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	d, err := client.GetDestination(srcSlug, slug)
+	d, err := client.GetDestination(c, srcSlug, slug)
 	if err != nil {
-		if IsNotFoundErr(err) {
+		switch ErrorKindOf(err) {
+		case ErrorKindNotFound:
 			r.SetId("")
 			return nil
-		} else {
-			return diag.FromErr(err)
+		default:
+			return apiErrorDiag(err, "cannot read destination %q of source %q", slug, srcSlug)
 		}
 	}
 
@@ -117,7 +169,38 @@ func resourceSegmentDestinationRead(c context.Context, r *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
-	configs, err := flattenDestinationConfigs(d.Configs)
+	// configs keep the name and the attribute they are declared with,
+	// the other ones get their full name and are sensitive according to their type
+	declared := declaredDestinationConfigFormats(r.Id(), r.Get("configs").(*schema.Set))
+	declaredSettings, err := decodeDestinationSettings(r.Get("settings").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	settings, dcs, err := flattenDestinationSettings(r.Id(), declaredSettings, d.Configs)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !r.Get("authoritative_configs").(bool) {
+		dcs = filterDeclaredDestinationConfigs(dcs, declared)
+	}
+	if _, ok := r.GetOk("settings"); ok {
+		if err := r.Set("settings", settings); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	var options map[string]bool
+	optionsRead := false
+	configs, err := flattenDestinationConfigs(dcs, func(dc segment.DestinationConfig) destinationConfigFormat {
+		if format, ok := declared[dc.Name]; ok {
+			return format
+		}
+		if !optionsRead {
+			options = readSensitiveDestinationOptions(c, client, slug)
+			optionsRead = true
+		}
+		return destinationConfigFormat{sensitive: isSensitiveDestinationConfig(dc, options), fullName: true}
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -129,42 +212,65 @@ func resourceSegmentDestinationRead(c context.Context, r *schema.ResourceData, m
 }
 
 func resourceSegmentDestinationUpdate(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*segment.Client)
+	client := meta.(*Client)
+
+	if !r.HasChangesExcept("deletion_protection") {
+		return resourceSegmentDestinationRead(c, r, meta)
+	}
 
 	slug := r.Get("slug").(string)
 	srcSlug := r.Get("source_slug").(string)
 	enabled := r.Get("enabled").(bool)
-	configs := r.Get("configs").(*schema.Set)
 
-	_, err := client.UpdateDestination(srcSlug, slug, enabled, extractDestinationConfigs(configs))
+	dcs, err := extractAllDestinationConfigs(c, client, slug, r.Id(), r)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if !r.Get("authoritative_configs").(bool) {
+		// the configs are replaced as a whole, so the ones not managed by Terraform have to be sent as well
+		current, err := client.GetDestination(c, srcSlug, slug)
+		if err != nil {
+			return apiErrorDiag(err, "cannot read configs of destination %q of source %q", slug, srcSlug)
+		}
+		dcs = mergeDestinationConfigs(current.Configs, dcs)
+	}
 
-	return resourceSegmentDestinationRead(c, r, meta)
+	_, err = client.UpdateDestination(c, srcSlug, slug, enabled, dcs)
+	if err != nil {
+		diags := apiErrorDiag(err, "cannot update destination %q of source %q", slug, srcSlug)
+		return redactDiag(diags, sensitiveDestinationConfigValues(c, client, slug, r))
+	}
+
+	return append(secretDestinationConfigValueWarnings(c, client, slug, r), resourceSegmentDestinationRead(c, r, meta)...)
 }
 
 func resourceSegmentDestinationDelete(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*segment.Client)
+	client := meta.(*Client)
 
-	slug := DestinationNameToSlug(r.Id())
-	srcSlug := DestinationNameToSourceSlug(r.Id())
-
-	err := client.DeleteDestination(srcSlug, slug)
+	srcSlug, slug, err := DestinationNameToSlugs(client.Workspace, r.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if diags := checkDeletionProtection(r, "destination", fmt.Sprintf("%s/%s", srcSlug, slug)); diags.HasError() {
+		return diags
+	}
+
+	err = client.DeleteDestination(c, srcSlug, slug)
+	if err != nil && ErrorKindOf(err) != ErrorKindNotFound {
+		return apiErrorDiag(err, "cannot delete destination %q of source %q", slug, srcSlug)
+	}
 
 	return nil
 }
 
-func extractDestinationConfigs(s *schema.Set) []segment.DestinationConfig {
+// extractDestinationConfigs returns the configs of the set, expanding the config keys to full names of the destination destName
+func extractDestinationConfigs(destName string, s *schema.Set) []segment.DestinationConfig {
 	configs := make([]segment.DestinationConfig, 0)
 
 	if s != nil {
 		for _, config := range s.List() {
 			c := segment.DestinationConfig{
-				Name:  config.(map[string]interface{})["name"].(string),
+				Name:  DestinationConfigKeyToName(destName, config.(map[string]interface{})["name"].(string)),
 				Type:  config.(map[string]interface{})["type"].(string),
 				Value: extractDestinationConfigValue(config),
 			}
@@ -177,6 +283,9 @@ func extractDestinationConfigs(s *schema.Set) []segment.DestinationConfig {
 
 func extractDestinationConfigValue(config interface{}) interface{} {
 	v := config.(map[string]interface{})["value"]
+	if sv := config.(map[string]interface{})["sensitive_value"]; sv != nil && sv.(string) != "" {
+		v = sv
+	}
 
 	if val, err := toJsonObject(v); err == nil {
 		return val
@@ -187,26 +296,84 @@ func extractDestinationConfigValue(config interface{}) interface{} {
 	return v
 }
 
-func flattenDestinationConfigs(dcs []segment.DestinationConfig) ([]interface{}, error) {
+// normalizedDestinationConfigValue returns the compact JSON encoding with sorted keys of values which are JSON objects or arrays,
+// i.e. those sent as such to Segment, and any other value unchanged
+func normalizedDestinationConfigValue(v interface{}) string {
+	s, _ := v.(string)
+	val, err := toJsonObject(s)
+	if err != nil {
+		val, err = toJsonArray(s)
+	}
+	if err != nil {
+		return s
+	}
+	j, err := json.Marshal(val)
+	if err != nil {
+		return s
+	}
+	return string(j)
+}
+
+func suppressEquivalentDestinationConfigValue(_, old, new string, _ *schema.ResourceData) bool {
+	return normalizedDestinationConfigValue(old) == normalizedDestinationConfigValue(new)
+}
+
+// destinationConfigHash hashes configs by their normalized values,
+// so that a config whose JSON value is only formatted differently keeps its place in the set
+func destinationConfigHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	var buf strings.Builder
+	for _, k := range []string{"name", "type"} {
+		s, _ := m[k].(string)
+		buf.WriteString(s)
+		buf.WriteString("\x00")
+	}
+	for _, k := range []string{"value", "sensitive_value"} {
+		buf.WriteString(normalizedDestinationConfigValue(m[k]))
+		buf.WriteString("\x00")
+	}
+	return schema.HashString(buf.String())
+}
+
+// destinationConfigFormat tells how a config is written to the state
+type destinationConfigFormat struct {
+	// sensitive configs have their value in sensitive_value instead of value
+	sensitive bool
+	// fullName configs are named by their full name instead of their key
+	fullName bool
+}
+
+func flattenDestinationConfigs(dcs []segment.DestinationConfig, format func(segment.DestinationConfig) destinationConfigFormat) ([]interface{}, error) {
 	if dcs != nil {
 		cs := make([]interface{}, len(dcs), len(dcs))
 
 		for i, dc := range dcs {
 			c := make(map[string]interface{})
 
+			f := format(dc)
+			valueKey := "value"
+			if f.sensitive {
+				valueKey = "sensitive_value"
+			}
 			if !IsNilOrZeroValue(dc.Value) {
 				switch v := dc.Value.(type) {
 				case string:
-					c["value"] = v
+					c[valueKey] = v
 				default:
 					jsonVal, err := json.Marshal(dc.Value)
 					if err != nil {
 						return nil, fmt.Errorf("cannot flatten configs: %w", err)
 					}
-					c["value"] = string(jsonVal)
+					c[valueKey] = string(jsonVal)
 				}
 			}
-			c["name"] = dc.Name
+			if v, ok := c[valueKey]; ok {
+				c[valueKey] = normalizedDestinationConfigValue(v)
+			}
+			c["name"] = DestinationConfigNameToKey(dc.Name)
+			if f.fullName {
+				c["name"] = dc.Name
+			}
 			c["type"] = dc.Type
 
 			cs[i] = c
@@ -218,10 +385,339 @@ func flattenDestinationConfigs(dcs []segment.DestinationConfig) ([]interface{},
 	return make([]interface{}, 0), nil
 }
 
-func DestinationNameToSlug(name string) string {
-	return strings.Split(name, "/")[5]
+// sensitiveOptionTypes are the types of catalog options and configs whose values are secret
+var sensitiveOptionTypes = map[string]bool{
+	"password": true,
+	"secret":   true,
+}
+
+// catalogDestinationOptions returns the options of a destination according to the catalog by key,
+// or nil if the destination is not in the catalog. As the catalog only provides defaults for what is not declared otherwise,
+// a temporary failure to read it is logged and ignored.
+func catalogDestinationOptions(c context.Context, client *Client, slug string) (map[string]CatalogOption, error) {
+	catalog, err := client.ListCatalogDestinations(c)
+	if err != nil {
+		if isTransientErr(err) {
+			log.Printf("[WARN] cannot read the options of destination %q from the catalog: %s", slug, err)
+			return nil, nil
+		}
+		return nil, err
+	}
+	for _, d := range catalog {
+		if d.Name != fmt.Sprintf("%s/%s", catalogDestinationsPath, slug) {
+			continue
+		}
+		options := make(map[string]CatalogOption, len(d.Settings))
+		for _, o := range d.Settings {
+			options[o.Name] = o
+		}
+		return options, nil
+	}
+	return nil, nil
+}
+
+// sensitiveDestinationOptions returns the keys of the options of a destination which are secret according to the catalog
+func sensitiveDestinationOptions(c context.Context, client *Client, slug string) (map[string]bool, error) {
+	catalogOptions, err := catalogDestinationOptions(c, client, slug)
+	if err != nil {
+		return nil, err
+	}
+	options := make(map[string]bool)
+	for key, o := range catalogOptions {
+		if sensitiveOptionTypes[o.Type] {
+			options[key] = true
+		}
+	}
+	return options, nil
 }
 
-func DestinationNameToSourceSlug(name string) string {
-	return strings.Split(name, "/")[3]
+// readSensitiveDestinationOptions returns the secret options of a destination for reading it, which does not depend on the catalog:
+// a failure to read it is logged, and only the configs which are secret by their own type are redacted then
+func readSensitiveDestinationOptions(c context.Context, client *Client, slug string) map[string]bool {
+	options, err := sensitiveDestinationOptions(c, client, slug)
+	if err != nil {
+		log.Printf("[WARN] cannot read the options of destination %q from the catalog: %s", slug, err)
+	}
+	return options
+}
+
+// isSensitiveDestinationConfig reports whether a config is secret, either by its own type or by the type of its catalog option
+func isSensitiveDestinationConfig(dc segment.DestinationConfig, options map[string]bool) bool {
+	return sensitiveOptionTypes[dc.Type] || options[DestinationConfigNameToKey(dc.Name)]
+}
+
+// declaredDestinationConfigFormats returns the format each config of the set is declared with,
+// by full name of the config of the destination destName
+func declaredDestinationConfigFormats(destName string, s *schema.Set) map[string]destinationConfigFormat {
+	declared := make(map[string]destinationConfigFormat)
+	if s != nil {
+		for _, config := range s.List() {
+			m := config.(map[string]interface{})
+			name := m["name"].(string)
+			declared[DestinationConfigKeyToName(destName, name)] = destinationConfigFormat{
+				sensitive: m["sensitive_value"].(string) != "",
+				fullName:  DestinationConfigKeyToName(destName, name) == name,
+			}
+		}
+	}
+	return declared
+}
+
+// sensitiveDestinationConfigValues returns the secret values of the configs and settings, i.e. those to be redacted from diagnostics
+func sensitiveDestinationConfigValues(c context.Context, client *Client, slug string, r *schema.ResourceData) []string {
+	values := make([]string, 0)
+	// this is called on errors only, which a failure to read the catalog would hide
+	options := readSensitiveDestinationOptions(c, client, slug)
+	for _, config := range r.Get("configs").(*schema.Set).List() {
+		m := config.(map[string]interface{})
+		if v := m["sensitive_value"].(string); v != "" {
+			values = append(values, v)
+			continue
+		}
+		dc := segment.DestinationConfig{Name: m["name"].(string), Type: m["type"].(string)}
+		if v := m["value"].(string); v != "" && isSensitiveDestinationConfig(dc, options) {
+			values = append(values, v)
+		}
+	}
+	// secret settings are rejected during plan, unless the catalog could not be read then
+	settings, _ := decodeDestinationSettings(r.Get("settings").(string))
+	for key, value := range settings {
+		if !options[key] {
+			continue
+		}
+		if v, ok := value.(string); ok {
+			values = append(values, v)
+			continue
+		}
+		if j, err := json.Marshal(value); err == nil {
+			values = append(values, string(j))
+		}
+	}
+	return values
+}
+
+// customizeDiffValidateDestinationConfigs checks that no config sets both value and sensitive_value.
+// Secrets set through value used to be accepted, so they are only warned about when applying, see secretDestinationConfigValueWarnings.
+func customizeDiffValidateDestinationConfigs(c context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("configs") {
+		return nil
+	}
+	for _, config := range diff.Get("configs").(*schema.Set).List() {
+		m := config.(map[string]interface{})
+		if m["value"].(string) != "" && m["sensitive_value"].(string) != "" {
+			return fmt.Errorf("config %q sets both value and sensitive_value, only one of them is allowed", m["name"].(string))
+		}
+	}
+	return nil
+}
+
+// secretDestinationConfigValueWarnings returns a deprecation warning for each config which is secret, according to its type
+// or the catalog, but set through value, which is shown in plans
+func secretDestinationConfigValueWarnings(c context.Context, client *Client, slug string, r *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var options map[string]bool
+	optionsRead := false
+	for _, config := range r.Get("configs").(*schema.Set).List() {
+		m := config.(map[string]interface{})
+		if m["value"].(string) == "" {
+			continue
+		}
+		dc := segment.DestinationConfig{Name: m["name"].(string), Type: m["type"].(string)}
+		if !sensitiveOptionTypes[dc.Type] && !optionsRead {
+			options = readSensitiveDestinationOptions(c, client, slug)
+			optionsRead = true
+		}
+		if isSensitiveDestinationConfig(dc, options) {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("config %q of destination %q is secret, setting it through value is deprecated", dc.Name, slug),
+				Detail:   "Its value is shown in plans. Set it through sensitive_value instead.",
+			})
+		}
+	}
+	return diags
+}
+
+// extractAllDestinationConfigs returns the configs declared either in configs or in settings of the destination destName
+func extractAllDestinationConfigs(c context.Context, client *Client, slug, destName string, r *schema.ResourceData) ([]segment.DestinationConfig, error) {
+	dcs := extractDestinationConfigs(destName, r.Get("configs").(*schema.Set))
+
+	settings, err := decodeDestinationSettings(r.Get("settings").(string))
+	if err != nil {
+		return nil, err
+	}
+	if len(settings) == 0 {
+		return dcs, nil
+	}
+	// the settings are validated against the catalog during plan, it only provides their types here
+	options, err := catalogDestinationOptions(c, client, slug)
+	if err != nil {
+		log.Printf("[WARN] cannot read the options of destination %q from the catalog, taking the types of its settings from their values: %s", slug, err)
+	}
+	for key, value := range settings {
+		dcs = append(dcs, segment.DestinationConfig{
+			Name:  DestinationConfigKeyToName(destName, key),
+			Type:  destinationSettingType(options, key, value),
+			Value: value,
+		})
+	}
+	return dcs, nil
+}
+
+// filterDeclaredDestinationConfigs returns the configs which are declared, by full name
+func filterDeclaredDestinationConfigs(dcs []segment.DestinationConfig, declared map[string]destinationConfigFormat) []segment.DestinationConfig {
+	filtered := make([]segment.DestinationConfig, 0, len(dcs))
+	for _, dc := range dcs {
+		if _, ok := declared[dc.Name]; ok {
+			filtered = append(filtered, dc)
+		}
+	}
+	return filtered
+}
+
+// mergeDestinationConfigs returns the current configs with the declared ones replacing or added to them.
+// Configs which are no longer declared are kept as they are, i.e. removing a config from the configuration stops managing it.
+func mergeDestinationConfigs(current, declared []segment.DestinationConfig) []segment.DestinationConfig {
+	indexes := make(map[string]int, len(current))
+	merged := make([]segment.DestinationConfig, 0, len(current)+len(declared))
+	for _, dc := range current {
+		indexes[dc.Name] = len(merged)
+		merged = append(merged, dc)
+	}
+	for _, dc := range declared {
+		if i, ok := indexes[dc.Name]; ok {
+			merged[i] = dc
+			continue
+		}
+		merged = append(merged, dc)
+	}
+	return merged
+}
+
+// decodeDestinationSettings returns the settings declared as JSON object by option key
+func decodeDestinationSettings(declared string) (map[string]interface{}, error) {
+	settings := make(map[string]interface{})
+	if declared == "" {
+		return settings, nil
+	}
+	if err := json.Unmarshal([]byte(declared), &settings); err != nil {
+		return nil, fmt.Errorf("invalid destination settings: %w", err)
+	}
+	return settings, nil
+}
+
+// flattenDestinationSettings returns the actual values of the declared settings as JSON object,
+// together with the remaining configs, which are not declared in settings
+func flattenDestinationSettings(destName string, declared map[string]interface{}, dcs []segment.DestinationConfig) (string, []segment.DestinationConfig, error) {
+	keys := make(map[string]string, len(declared))
+	for key := range declared {
+		keys[DestinationConfigKeyToName(destName, key)] = key
+	}
+
+	settings := make(map[string]interface{})
+	remaining := make([]segment.DestinationConfig, 0, len(dcs))
+	for _, dc := range dcs {
+		if key, ok := keys[dc.Name]; ok {
+			settings[key] = dc.Value
+			continue
+		}
+		remaining = append(remaining, dc)
+	}
+
+	j, err := json.Marshal(settings)
+	if err != nil {
+		return "", nil, fmt.Errorf("cannot flatten destination settings: %w", err)
+	}
+	return string(j), remaining, nil
+}
+
+// destinationSettingType returns the config type of a setting, i.e. the type of its catalog option
+// or, for options missing from the catalog, the one matching its JSON value
+func destinationSettingType(options map[string]CatalogOption, key string, value interface{}) string {
+	if o, ok := options[key]; ok && o.Type != "" {
+		return o.Type
+	}
+	switch value.(type) {
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "map"
+	default:
+		return "string"
+	}
+}
+
+// customizeDiffValidateDestinationSettings checks that settings are not declared in configs as well
+// and that their keys are options of the destination in the catalog, so that a typo is reported during plan.
+// Secret options are rejected, as settings are not redacted in plans.
+func customizeDiffValidateDestinationSettings(c context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("settings") || !diff.NewValueKnown("slug") {
+		return nil
+	}
+	settings, err := decodeDestinationSettings(diff.Get("settings").(string))
+	if err != nil || len(settings) == 0 {
+		return err
+	}
+
+	if diff.NewValueKnown("configs") {
+		for _, config := range diff.Get("configs").(*schema.Set).List() {
+			key := DestinationConfigNameToKey(config.(map[string]interface{})["name"].(string))
+			if _, ok := settings[key]; ok {
+				return fmt.Errorf("%q is declared both in configs and in settings, only one of them is allowed", key)
+			}
+		}
+	}
+
+	client := meta.(*Client)
+	slug := diff.Get("slug").(string)
+	options, err := catalogDestinationOptions(c, client, slug)
+	if err != nil {
+		return fmt.Errorf("cannot read the options of destination %q from the catalog: %w", slug, err)
+	}
+	if len(options) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	for key := range settings {
+		if o, ok := options[key]; ok {
+			if sensitiveOptionTypes[o.Type] {
+				return fmt.Errorf("setting %q is secret according to the catalog, declare it in configs with sensitive_value instead", key)
+			}
+			continue
+		}
+		suggestions := closestMatches(key, keys, 3)
+		if len(suggestions) == 0 {
+			return fmt.Errorf("setting %q is not an option of destination %q in the catalog", key, slug)
+		}
+		for i, s := range suggestions {
+			suggestions[i] = fmt.Sprintf("%q", s)
+		}
+		return fmt.Errorf("setting %q is not an option of destination %q in the catalog, did you mean %s?", key, slug, strings.Join(suggestions, " or "))
+	}
+	return nil
+}
+
+func resourceSegmentDestinationImport(c context.Context, r *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client)
+
+	srcSlug, slug, err := ParseDestinationImportId(client.Workspace, r.Id())
+	if err != nil {
+		return nil, err
+	}
+	r.SetId(DestinationSlugToName(client.Workspace, srcSlug, slug))
+	if err := r.Set("deletion_protection", false); err != nil {
+		return nil, err
+	}
+	if err := r.Set("authoritative_configs", true); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{r}, nil
 }
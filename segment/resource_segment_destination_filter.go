@@ -9,7 +9,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"strings"
 )
 
 func resourceSegmentDestinationFilter() *schema.Resource {
@@ -121,6 +120,12 @@ func resourceSegmentDestinationFilter() *schema.Resource {
 				},
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 		CreateContext: resourceSegmentDestinationFilterCreate,
 		ReadContext:   resourceSegmentDestinationFilterRead,
 		UpdateContext: resourceSegmentDestinationFilterUpdate,
@@ -135,7 +140,7 @@ func resourceSegmentDestinationFilter() *schema.Resource {
 }
 
 func resourceSegmentDestinationFilterCreate(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*segment.Client)
+	client := meta.(*Client)
 
 	srcSlug := r.Get("source_slug").(string)
 	dstSlug := r.Get("destination_slug").(string)
@@ -153,33 +158,39 @@ func resourceSegmentDestinationFilterCreate(c context.Context, r *schema.Resourc
 		Actions:     extractDestinationFiltersActions(actions),
 	}
 
-	df, err := client.CreateDestinationFilter(srcSlug, dstSlug, filter)
+	df, err := client.CreateDestinationFilter(c, srcSlug, dstSlug, filter)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiag(err, "cannot create filter %q of destination %q of source %q", title, dstSlug, srcSlug)
 	}
 
 	if err := r.Set("name", df.Name); err != nil {
 		return diag.FromErr(err)
 	}
-	r.SetId(DestinationFilterNameToId(df.Name))
+	_, _, id, err := DestinationFilterNameToIds(client.Workspace, df.Name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	r.SetId(id)
 
 	return resourceSegmentDestinationFilterRead(c, r, meta)
 }
 
 func resourceSegmentDestinationFilterRead(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*segment.Client)
+	client := meta.(*Client)
 
 	id := r.Id()
 	srcSlug := r.Get("source_slug").(string)
 	dstSlug := r.Get("destination_slug").(string)
 
-	df, err := client.GetDestinationFilter(srcSlug, dstSlug, id)
+	df, err := client.GetDestinationFilter(c, srcSlug, dstSlug, id)
 	if err != nil {
-		if IsNotFoundErr(err) || Is500ValidatePermissionsErr(err) {
+		switch {
+		// the Segment API returns a 500 instead of a 404 for missing filters
+		case IsNotFoundErr(err) || Is500ValidatePermissionsErr(err):
 			r.SetId("")
 			return nil
-		} else {
-			return diag.FromErr(err)
+		default:
+			return apiErrorDiag(err, "cannot read filter %q of destination %q of source %q", id, dstSlug, srcSlug)
 		}
 	}
 	if err := r.Set("name", df.Name); err != nil {
@@ -209,7 +220,7 @@ func resourceSegmentDestinationFilterRead(c context.Context, r *schema.ResourceD
 }
 
 func resourceSegmentDestinationFilterUpdate(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*segment.Client)
+	client := meta.(*Client)
 
 	name := r.Get("name").(string)
 	srcSlug := r.Get("source_slug").(string)
@@ -229,31 +240,34 @@ func resourceSegmentDestinationFilterUpdate(c context.Context, r *schema.Resourc
 		Actions:     extractDestinationFiltersActions(actions),
 	}
 
-	_, err := client.UpdateDestinationFilter(srcSlug, dstSlug, filter)
+	_, err := client.UpdateDestinationFilter(c, srcSlug, dstSlug, filter)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiag(err, "cannot update filter %q of destination %q of source %q", name, dstSlug, srcSlug)
 	}
 
 	return resourceSegmentDestinationFilterRead(c, r, meta)
 }
 
 func resourceSegmentDestinationFilterDelete(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*segment.Client)
+	client := meta.(*Client)
 
 	id := r.Id()
 	srcSlug := r.Get("source_slug").(string)
 	dstSlug := r.Get("destination_slug").(string)
 
-	if err := client.DeleteDestinationFilter(srcSlug, dstSlug, id); err != nil {
-		return diag.FromErr(err)
+	if err := client.DeleteDestinationFilter(c, srcSlug, dstSlug, id); err != nil && ErrorKindOf(err) != ErrorKindNotFound {
+		return apiErrorDiag(err, "cannot delete filter %q of destination %q of source %q", id, dstSlug, srcSlug)
 	}
 	return nil
 }
 
 func resourceSegmentDestinationFilterImport(c context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	srcSlug := SourceNameToSlug(d.Id())
-	dstSlug := DestinationNameToSlug(d.Id())
-	id := DestinationFilterNameToId(d.Id())
+	client := m.(*Client)
+
+	srcSlug, dstSlug, id, err := ParseDestinationFilterImportId(client.Workspace, d.Id())
+	if err != nil {
+		return nil, err
+	}
 
 	if err := d.Set("source_slug", srcSlug); err != nil {
 		return nil, err
@@ -356,10 +370,6 @@ func flattenDestinationFilterActionsFields(actionFields segment.EventDescription
 	return []interface{}{fields}
 }
 
-func DestinationFilterNameToId(name string) string {
-	return strings.Split(name, "/")[9]
-}
-
 func customizeDiffValidateDestinationFilterActions(c context.Context, diff *schema.ResourceDiff, v interface{}) error {
 	fieldsKeys := []string{"context", "properties", "traits"}
 
@@ -1,6 +1,7 @@
 package segment_test
 
 import (
+	"context"
 	"fmt"
 	segmentapi "github.com/forteilgmbh/segment-config-go/segment"
 	"github.com/forteilgmbh/terraform-provider-segment/segment"
@@ -120,6 +121,18 @@ func TestAccSegmentDestinationFilter_basic(t *testing.T) {
 				},
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName: "segment_destination_filter.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["segment_destination_filter.test"]
+					if !ok {
+						return "", fmt.Errorf("not found: segment_destination_filter.test")
+					}
+					return fmt.Sprintf("%s/webhooks/%s", srcSlug, rs.Primary.ID), nil
+				},
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -148,7 +161,7 @@ func TestAccSegmentDestinationFilter_disappears(t *testing.T) {
 }
 
 func testAccCheckSegmentDestinationFilterDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*segmentapi.Client)
+	client := testAccProvider.Meta().(*segment.Client)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "segment_destination_filter" {
@@ -158,7 +171,7 @@ func testAccCheckSegmentDestinationFilterDestroy(s *terraform.State) error {
 		srcSlug := rs.Primary.Attributes["source_slug"]
 		dstSlug := rs.Primary.Attributes["destination_slug"]
 
-		_, err := client.GetDestinationFilter(srcSlug, dstSlug, id)
+		_, err := client.GetDestinationFilter(context.Background(), srcSlug, dstSlug, id)
 
 		if err == nil {
 			return fmt.Errorf("destination filter %q still exists", rs.Primary.ID)
@@ -181,13 +194,13 @@ func testAccCheckDestinationFilterExists(name string, df *segmentapi.Destination
 		if rs.Primary.ID == "" {
 			return fmt.Errorf("destination filter %q has no ID set", name)
 		}
-		client := testAccProvider.Meta().(*segmentapi.Client)
+		client := testAccProvider.Meta().(*segment.Client)
 
 		id := rs.Primary.ID
 		srcSlug := rs.Primary.Attributes["source_slug"]
 		dstSlug := rs.Primary.Attributes["destination_slug"]
 
-		resp, err := client.GetDestinationFilter(srcSlug, dstSlug, id)
+		resp, err := client.GetDestinationFilter(context.Background(), srcSlug, dstSlug, id)
 		if err != nil {
 			return err
 		}
@@ -203,8 +216,8 @@ func testAccCheckDestinationFilterDisappears(name string) resource.TestCheckFunc
 		id := rs.Primary.ID
 		srcSlug := rs.Primary.Attributes["source_slug"]
 		dstSlug := rs.Primary.Attributes["destination_slug"]
-		client := testAccProvider.Meta().(*segmentapi.Client)
-		return client.DeleteDestinationFilter(srcSlug, dstSlug, id)
+		client := testAccProvider.Meta().(*segment.Client)
+		return client.DeleteDestinationFilter(context.Background(), srcSlug, dstSlug, id)
 	}
 }
 
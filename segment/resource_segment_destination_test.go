@@ -1,6 +1,7 @@
 package segment_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	segmentapi "github.com/forteilgmbh/segment-config-go/segment"
@@ -9,6 +10,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -30,6 +35,12 @@ func TestAccSegmentDestination_webhook(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:      "segment_destination.test",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s/webhooks", srcSlug),
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -52,6 +63,241 @@ func testAccSegmentDestinationStep_webhook(resourceName string, srcSlug string,
 	}
 }
 
+func TestAccSegmentDestination_sensitiveValue(t *testing.T) {
+	var destination segmentapi.Destination
+	resourceName := "segment_destination.test"
+	srcSlug := acctest.RandomWithPrefix("tf-testacc-dst-sensitive")
+	endpoint := "https://example.com/api/v1"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckSegmentDestinationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSegmentDestinationConfig_bothValues(srcSlug, endpoint),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`sets both value and sensitive_value`),
+			},
+			{
+				Config: testAccSegmentDestinationConfig_sensitiveValue(srcSlug, endpoint),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDestinationExists(resourceName, &destination),
+					testAccCheckDestinationAttributes_webhook(resourceName, &destination, true, endpoint),
+					resource.TestCheckResourceAttr(resourceName, "configs.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "configs.*", map[string]string{
+						"name":            segment.DestinationSlugToName(os.Getenv("SEGMENT_WORKSPACE"), srcSlug, "webhooks") + "/config/sharedSecret",
+						"value":           "",
+						"sensitive_value": "secretValue",
+						"type":            "string",
+					}),
+				),
+			},
+			{
+				// switching between value and sensitive_value only changes the state
+				Config: testAccSegmentDestinationConfig_webhook(srcSlug, true, endpoint),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDestinationConfigs_webhook(resourceName, srcSlug, endpoint),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSegmentDestination_configKeys(t *testing.T) {
+	var destination segmentapi.Destination
+	resourceName := "segment_destination.test"
+	srcSlug := acctest.RandomWithPrefix("tf-testacc-dst-keys")
+	endpoint := "https://example.com/api/v1"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckSegmentDestinationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSegmentDestinationConfig_configKeys(srcSlug, endpoint),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDestinationExists(resourceName, &destination),
+					testAccCheckDestinationAttributes_webhook(resourceName, &destination, true, endpoint),
+					resource.TestCheckResourceAttr(resourceName, "configs.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "configs.*", map[string]string{
+						"name":  "sharedSecret",
+						"value": "secretValue",
+						"type":  "string",
+					}),
+				),
+			},
+			{
+				// switching to full names only changes the state
+				Config: testAccSegmentDestinationConfig_webhook(srcSlug, true, endpoint),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDestinationConfigs_webhook(resourceName, srcSlug, endpoint),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSegmentDestination_settings(t *testing.T) {
+	var destination segmentapi.Destination
+	resourceName := "segment_destination.test"
+	srcSlug := acctest.RandomWithPrefix("tf-testacc-dst-settings")
+	endpoint := "https://example.com/api/v1"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckSegmentDestinationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSegmentDestinationConfig_settings(srcSlug, endpoint, "globalHookk"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`setting "globalHookk" is not an option of destination "webhooks" in the catalog, did you mean "globalHook"`),
+			},
+			{
+				Config: testAccSegmentDestinationConfig_settings(srcSlug, endpoint, "globalHook"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDestinationExists(resourceName, &destination),
+					testAccCheckDestinationAttributes_webhook(resourceName, &destination, true, endpoint),
+					resource.TestCheckResourceAttr(resourceName, "configs.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "settings", `{"globalHook":""}`),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "configs.*", map[string]string{
+						"name":            "sharedSecret",
+						"value":           "",
+						"sensitive_value": "secretValue",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSegmentDestination_nonauthoritative(t *testing.T) {
+	var destination segmentapi.Destination
+	resourceName := "segment_destination.test"
+	srcSlug := acctest.RandomWithPrefix("tf-testacc-dst-nonauth")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckSegmentDestinationDestroy,
+		Steps: []resource.TestStep{
+			// regular setup, then sharedSecret is added outside of Terraform
+			{
+				Config: testAccSegmentDestinationConfig_nonauthoritative(srcSlug, "https://example.com/api/v1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDestinationExists(resourceName, &destination),
+					resource.TestCheckResourceAttr(resourceName, "configs.#", "1"),
+					testAccAddDestinationSharedSecret(resourceName, &destination),
+				),
+			},
+			// sharedSecret is set in the actual destination, but it is not managed in state
+			{
+				Config: testAccSegmentDestinationConfig_nonauthoritative(srcSlug, "https://example.com/api/v1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDestinationExists(resourceName, &destination),
+					testAccCheckDestinationSharedSecret(&destination),
+					resource.TestCheckResourceAttr(resourceName, "configs.#", "1"),
+				),
+			},
+			// the unmanaged config is not modified while modifying the managed ones
+			{
+				Config: testAccSegmentDestinationConfig_nonauthoritative(srcSlug, "https://example.com/api/v2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDestinationExists(resourceName, &destination),
+					testAccCheckDestinationSharedSecret(&destination),
+					resource.TestCheckResourceAttr(resourceName, "configs.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "configs.*", map[string]string{
+						"name":  "hooks",
+						"value": toJsonString(testAccSegmentDestination_webhookConfigsHooksValue("https://example.com/api/v2")),
+					}),
+				),
+			},
+			// a config added to the configuration is managed from then on
+			{
+				Config: testAccSegmentDestinationConfig_nonauthoritativeGlobalHook(srcSlug, "https://example.com/api/v2", "https://example.com/global"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDestinationExists(resourceName, &destination),
+					testAccCheckDestinationGlobalHook(&destination, "https://example.com/global"),
+					resource.TestCheckResourceAttr(resourceName, "configs.#", "2"),
+				),
+			},
+			// removing it from the configuration stops managing it, but keeps it in the destination
+			{
+				Config: testAccSegmentDestinationConfig_nonauthoritative(srcSlug, "https://example.com/api/v2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDestinationExists(resourceName, &destination),
+					testAccCheckDestinationGlobalHook(&destination, "https://example.com/global"),
+					testAccCheckDestinationSharedSecret(&destination),
+					resource.TestCheckResourceAttr(resourceName, "configs.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDestinationGlobalHook(destination *segmentapi.Destination, globalHook string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if !anyDestinationConfigValid(destination.Configs, func(c segmentapi.DestinationConfig) bool {
+			return c.Name == destination.Name+"/config/globalHook" && c.Value == globalHook
+		}) {
+			return fmt.Errorf("not found correct Config (globalHook) in destination.Configs: %+v", destination.Configs)
+		}
+		return nil
+	}
+}
+
+func testAccAddDestinationSharedSecret(name string, destination *segmentapi.Destination) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs := s.RootModule().Resources[name]
+		client := testAccProvider.Meta().(*segment.Client)
+
+		srcSlug, slug, err := segment.DestinationNameToSlugs(client.Workspace, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		configs := append(destination.Configs, segmentapi.DestinationConfig{
+			Name:  rs.Primary.ID + "/config/sharedSecret",
+			Type:  "string",
+			Value: "secretValue",
+		})
+		_, err = client.UpdateDestination(context.Background(), srcSlug, slug, destination.Enabled, configs)
+		return err
+	}
+}
+
+func testAccCheckDestinationSharedSecret(destination *segmentapi.Destination) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if !anyDestinationConfigValid(destination.Configs, func(c segmentapi.DestinationConfig) bool {
+			return c.Name == destination.Name+"/config/sharedSecret" && c.Value == "secretValue"
+		}) {
+			return fmt.Errorf("not found unmanaged Config (sharedSecret) in destination.Configs: %+v", destination.Configs)
+		}
+		return nil
+	}
+}
+
+func TestAccSegmentDestination_jsonValue(t *testing.T) {
+	resourceName := "segment_destination.test"
+	srcSlug := acctest.RandomWithPrefix("tf-testacc-dst-json")
+	endpoint := "https://example.com/api/v1"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckSegmentDestinationDestroy,
+		Steps: []resource.TestStep{
+			testAccSegmentDestinationStep_webhook(resourceName, srcSlug, true, endpoint),
+			// the same value, formatted differently and with other key order, results in no changes
+			{
+				Config:   testAccSegmentDestinationConfig_formattedJson(srcSlug, endpoint),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func TestAccSegmentDestination_disappears(t *testing.T) {
 	var destination segmentapi.Destination
 	srcSlug := acctest.RandomWithPrefix("tf-testacc-dst-disappears")
@@ -73,17 +319,67 @@ func TestAccSegmentDestination_disappears(t *testing.T) {
 	})
 }
 
+func TestAccSegmentDestination_deletionProtection(t *testing.T) {
+	var destinationBefore, destinationAfter segmentapi.Destination
+	resourceName := "segment_destination.test"
+	srcSlug := acctest.RandomWithPrefix("tf-testacc-dst-protected")
+	endpoint := "https://example.com/api/v1"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckSegmentDestinationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSegmentDestinationConfig_deletionProtection(srcSlug, endpoint, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDestinationExists(resourceName, &destinationBefore),
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      testAccSegmentDestinationConfig_deletionProtection(srcSlug, endpoint, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("deletion protection is enabled"),
+			},
+			// turning it off does not modify the destination
+			{
+				Config: testAccSegmentDestinationConfig_deletionProtection(srcSlug, endpoint, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDestinationExists(resourceName, &destinationAfter),
+					testAccCheckDestinationNotModified(&destinationBefore, &destinationAfter),
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDestinationNotModified(before, after *segmentapi.Destination) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if !before.CreateTime.Equal(after.CreateTime) {
+			return fmt.Errorf("destination %q was recreated", after.Name)
+		}
+		if !before.UpdateTime.Equal(after.UpdateTime) {
+			return fmt.Errorf("destination %q was updated", after.Name)
+		}
+		return nil
+	}
+}
+
 func testAccCheckSegmentDestinationDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*segmentapi.Client)
+	client := testAccProvider.Meta().(*segment.Client)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "segment_destination" {
 			continue
 		}
-		slug := segment.DestinationNameToSlug(rs.Primary.ID)
-		srcSlug := segment.DestinationNameToSourceSlug(rs.Primary.ID)
+		srcSlug, slug, err := segment.DestinationNameToSlugs(client.Workspace, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
 
-		_, err := client.GetDestination(srcSlug, slug)
+		_, err = client.GetDestination(context.Background(), srcSlug, slug)
 
 		if err == nil {
 			return fmt.Errorf("destination %q still exists", rs.Primary.ID)
@@ -106,12 +402,14 @@ func testAccCheckDestinationExists(name string, destination *segmentapi.Destinat
 		if rs.Primary.ID == "" {
 			return fmt.Errorf("destination %q has no ID set", name)
 		}
-		client := testAccProvider.Meta().(*segmentapi.Client)
+		client := testAccProvider.Meta().(*segment.Client)
 
-		slug := segment.DestinationNameToSlug(rs.Primary.ID)
-		srcSlug := segment.DestinationNameToSourceSlug(rs.Primary.ID)
+		srcSlug, slug, err := segment.DestinationNameToSlugs(client.Workspace, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
 
-		resp, err := client.GetDestination(srcSlug, slug)
+		resp, err := client.GetDestination(context.Background(), srcSlug, slug)
 		if err != nil {
 			return err
 		}
@@ -123,12 +421,14 @@ func testAccCheckDestinationExists(name string, destination *segmentapi.Destinat
 
 func testAccCheckDestinationDisappears(destination *segmentapi.Destination) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*segmentapi.Client)
+		client := testAccProvider.Meta().(*segment.Client)
 
-		slug := segment.DestinationNameToSlug(destination.Name)
-		srcSlug := segment.DestinationNameToSourceSlug(destination.Name)
+		srcSlug, slug, err := segment.DestinationNameToSlugs(client.Workspace, destination.Name)
+		if err != nil {
+			return err
+		}
 
-		return client.DeleteDestination(srcSlug, slug)
+		return client.DeleteDestination(context.Background(), srcSlug, slug)
 	}
 }
 
@@ -170,7 +470,7 @@ func testAccCheckDestinationAttributes_webhook(name string, destination *segment
 
 func testAccCheckDestinationConfigs_webhook(resourceName, srcSlug, endpoint string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*segmentapi.Client)
+		client := testAccProvider.Meta().(*segment.Client)
 
 		configBaseName := fmt.Sprintf("workspaces/%s/sources/%s/destinations/webhooks/config/", client.Workspace, srcSlug)
 		globalHook := map[string]string{
@@ -238,6 +538,130 @@ resource "segment_destination" "test" {
 	)
 }
 
+func testAccSegmentDestinationConfig_sensitiveValue(srcSlug string, endpoint string) string {
+	return strings.Replace(testAccSegmentDestinationConfig_webhook(srcSlug, true, endpoint),
+		`value = "secretValue"`, `sensitive_value = "secretValue"`, 1)
+}
+
+func testAccSegmentDestinationConfig_deletionProtection(srcSlug string, endpoint string, deletionProtection bool) string {
+	return strings.Replace(testAccSegmentDestinationConfig_sensitiveValue(srcSlug, endpoint),
+		`enabled          = true`, fmt.Sprintf(`enabled          = true
+  deletion_protection = %t`, deletionProtection), 1)
+}
+
+func testAccSegmentDestinationConfig_bothValues(srcSlug string, endpoint string) string {
+	return strings.Replace(testAccSegmentDestinationConfig_webhook(srcSlug, true, endpoint),
+		`value = "secretValue"`, `value = "secretValue"
+    sensitive_value = "secretValue"`, 1)
+}
+
+func testAccSegmentDestinationConfig_configKeys(srcSlug string, endpoint string) string {
+	return strings.ReplaceAll(testAccSegmentDestinationConfig_webhook(srcSlug, true, endpoint),
+		"${segment_source.test.id}/destinations/webhooks/config/", "")
+}
+
+func testAccSegmentDestinationConfig_settings(srcSlug string, endpoint string, globalHookKey string) string {
+	return configCompose(
+		testAccSegmentSourceConfig_basic(srcSlug, "catalog/sources/net"),
+		fmt.Sprintf(`
+resource "segment_destination" "test" {
+  slug             = "webhooks"
+  source_slug      = segment_source.test.slug
+  connection_mode  = "UNSPECIFIED"
+  enabled          = true
+
+  configs {
+    name = "hooks"
+    value = jsonencode([
+      {
+        hook = %q
+        headers = [
+          {
+            "key"   = "Authorization"
+            "value" = "Basic d2h5OmFyZXlvdWV2ZW5kZWNvZGluZ3RoaXM/Pz8="
+          }
+        ]
+      }
+    ])
+    type = "mixed"
+  }
+
+  configs {
+    name            = "sharedSecret"
+    sensitive_value = "secretValue"
+    type            = "string"
+  }
+
+  settings = jsonencode({
+    %s = ""
+  })
+}
+`, endpoint, globalHookKey),
+	)
+}
+
+func testAccSegmentDestinationConfig_nonauthoritative(srcSlug string, endpoint string) string {
+	return configCompose(
+		testAccSegmentSourceConfig_basic(srcSlug, "catalog/sources/net"),
+		fmt.Sprintf(`
+resource "segment_destination" "test" {
+  slug             = "webhooks"
+  source_slug      = segment_source.test.slug
+  connection_mode  = "UNSPECIFIED"
+  enabled          = true
+
+  authoritative_configs = false
+
+  configs {
+    name = "hooks"
+    value = jsonencode([
+      {
+        hook = %q
+        headers = [
+          {
+            "key"   = "Authorization"
+            "value" = "Basic d2h5OmFyZXlvdWV2ZW5kZWNvZGluZ3RoaXM/Pz8="
+          }
+        ]
+      }
+    ])
+    type = "mixed"
+  }
+}
+`, endpoint),
+	)
+}
+
+func testAccSegmentDestinationConfig_nonauthoritativeGlobalHook(srcSlug string, endpoint string, globalHook string) string {
+	return strings.Replace(testAccSegmentDestinationConfig_nonauthoritative(srcSlug, endpoint),
+		`authoritative_configs = false`, fmt.Sprintf(`authoritative_configs = false
+
+  configs {
+    name  = "globalHook"
+    value = %q
+    type  = "string"
+  }`, globalHook), 1)
+}
+
+func testAccSegmentDestinationConfig_formattedJson(srcSlug string, endpoint string) string {
+	config := testAccSegmentDestinationConfig_webhook(srcSlug, true, endpoint)
+	start := strings.Index(config, "value = jsonencode(")
+	end := strings.Index(config[start:], "])") + start + len("])")
+	return config[:start] + fmt.Sprintf(`value = <<-EOT
+      [
+        {
+          "headers": [
+            {
+              "value": "Basic d2h5OmFyZXlvdWV2ZW5kZWNvZGluZ3RoaXM/Pz8=",
+              "key":   "Authorization"
+            }
+          ],
+          "hook": %q
+        }
+      ]
+    EOT`, endpoint) + config[end:]
+}
+
 func testAccSegmentDestination_webhookConfigsHooksValue(endpoint string) interface{} {
 	h := map[string]interface{}{
 		"key":   "Authorization",
@@ -264,3 +688,177 @@ func toJsonString(v interface{}) string {
 	s, _ := json.Marshal(v)
 	return string(s)
 }
+
+func TestSegmentDestination_secretValues(t *testing.T) {
+	var catalogRequests int
+	res, client := newTestResource(t, "segment_destination", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1beta/catalog/destinations":
+			catalogRequests++
+			fmt.Fprint(w, `{"destinations": [{
+				"name": "catalog/destinations/webhooks",
+				"connection_modes": ["CLOUD"],
+				"settings": [
+					{"name": "globalHook", "type": "string"},
+					{"name": "sharedSecret", "type": "password"}
+				]
+			}]}`)
+		case "POST /v1beta/workspaces/myworkspace/sources/ios/destinations",
+			"GET /v1beta/workspaces/myworkspace/sources/ios/destinations/webhooks":
+			fmt.Fprint(w, `{"name": "workspaces/myworkspace/sources/ios/destinations/webhooks", "connection_mode": "CLOUD", "enabled": true}`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	cases := []struct {
+		name     string
+		config   map[string]interface{}
+		expected string
+		warning  string
+	}{
+		// secrets set through value used to be accepted, they are deprecated but still applied
+		{
+			name: "value of secret type",
+			config: map[string]interface{}{"configs": []interface{}{
+				map[string]interface{}{"name": "apiKey", "type": "secret", "value": "secretValue"},
+			}},
+			warning: `config "apiKey" of destination "webhooks" is secret, setting it through value is deprecated`,
+		},
+		{
+			name: "value of secret catalog option",
+			config: map[string]interface{}{"configs": []interface{}{
+				map[string]interface{}{"name": "sharedSecret", "type": "string", "value": "secretValue"},
+			}},
+			warning: `config "sharedSecret" of destination "webhooks" is secret, setting it through value is deprecated`,
+		},
+		{
+			name: "value and sensitive value",
+			config: map[string]interface{}{"configs": []interface{}{
+				map[string]interface{}{"name": "sharedSecret", "type": "string", "value": "secretValue", "sensitive_value": "secretValue"},
+			}},
+			expected: `config "sharedSecret" sets both value and sensitive_value, only one of them is allowed`,
+		},
+		{
+			name:     "setting of secret catalog option",
+			config:   map[string]interface{}{"settings": `{"sharedSecret": "secretValue"}`},
+			expected: `setting "sharedSecret" is secret according to the catalog, declare it in configs with sensitive_value instead`,
+		},
+		{
+			name: "sensitive value",
+			config: map[string]interface{}{
+				"configs": []interface{}{
+					map[string]interface{}{"name": "sharedSecret", "type": "string", "sensitive_value": "secretValue"},
+				},
+				"settings": `{"globalHook": "https://example.com"}`,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"slug":            "webhooks",
+				"source_slug":     "ios",
+				"connection_mode": "CLOUD",
+			}
+			for k, v := range c.config {
+				raw[k] = v
+			}
+			catalogRequests = 0
+			diff, err := res.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), client)
+			if c.expected != "" {
+				if err == nil || !strings.Contains(err.Error(), c.expected) {
+					t.Errorf("invalid error: expected: %q, actual: %v", c.expected, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			// only settings are validated against the catalog during plan
+			if _, ok := c.config["settings"]; !ok && catalogRequests != 0 {
+				t.Errorf("unexpected catalog requests during plan: %d", catalogRequests)
+			}
+
+			_, diags := res.Apply(context.Background(), nil, diff, client)
+			if diags.HasError() {
+				t.Fatalf("unexpected apply error: %v", diags)
+			}
+			switch {
+			case c.warning == "" && len(diags) != 0:
+				t.Errorf("unexpected warnings: %v", diags)
+			case c.warning != "" && (len(diags) != 1 || diags[0].Summary != c.warning):
+				t.Errorf("invalid warnings: expected: %q, actual: %v", c.warning, diags)
+			}
+		})
+	}
+}
+
+func TestSegmentDestination_catalogError(t *testing.T) {
+	cases := []struct {
+		status   int
+		expected string
+	}{
+		// the validation is skipped on temporary failures
+		{http.StatusServiceUnavailable, ""},
+		{http.StatusTooManyRequests, ""},
+		{http.StatusForbidden, `cannot read the options of destination "webhooks" from the catalog`},
+	}
+
+	for _, c := range cases {
+		res, client := newTestResource(t, "segment_destination", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(c.status)
+		})
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"slug":            "webhooks",
+			"source_slug":     "ios",
+			"connection_mode": "CLOUD",
+			"settings":        `{"globalHook": "https://example.com"}`,
+		})
+
+		_, err := res.Diff(context.Background(), nil, config, client)
+		switch {
+		case c.expected == "" && err != nil:
+			t.Errorf("unexpected error for %d: %s", c.status, err)
+		case c.expected != "" && (err == nil || !strings.Contains(err.Error(), c.expected)):
+			t.Errorf("invalid error for %d: expected: %q, actual: %v", c.status, c.expected, err)
+		}
+	}
+}
+
+func TestSegmentDestination_readCatalogError(t *testing.T) {
+	res, client := newTestResource(t, "segment_destination", func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/catalog/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		fmt.Fprint(w, `{
+			"name": "workspaces/myworkspace/sources/ios/destinations/webhooks",
+			"connection_mode": "CLOUD",
+			"enabled": true,
+			"config": [
+				{"name": "workspaces/myworkspace/sources/ios/destinations/webhooks/config/apiKey", "type": "secret", "value": "secretValue"},
+				{"name": "workspaces/myworkspace/sources/ios/destinations/webhooks/config/sharedSecret", "type": "string", "value": "otherValue"}
+			]
+		}`)
+	})
+	state := &terraform.InstanceState{
+		ID: "workspaces/myworkspace/sources/ios/destinations/webhooks",
+		Attributes: map[string]string{
+			"id":                    "workspaces/myworkspace/sources/ios/destinations/webhooks",
+			"slug":                  "webhooks",
+			"source_slug":           "ios",
+			"authoritative_configs": "true",
+		},
+	}
+
+	// the catalog only decides which undeclared configs are redacted, it does not prevent reading the destination
+	actual, diags := res.RefreshWithoutUpgrade(context.Background(), state, client)
+	if diags.HasError() {
+		t.Fatalf("unexpected refresh error: %v", diags)
+	}
+	if actual == nil || actual.Attributes["configs.#"] != "2" {
+		t.Errorf("invalid configs: %v", actual)
+	}
+}
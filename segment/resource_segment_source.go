@@ -2,11 +2,16 @@ package segment
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"github.com/forteilgmbh/segment-config-go/segment"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
 	"strings"
+	"time"
 )
 
 func resourceSegmentSource() *schema.Resource {
@@ -24,45 +29,119 @@ func resourceSegmentSource() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			"display_name": {
+				Description: "Display name of the source, defaults to the one assigned by Segment",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"enabled": {
+				Description: "Whether the source accepts data, defaults to the state assigned by Segment",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"labels": {
+				Description: `Labels of the source (e.g. {environment = "prod"})`,
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"settings": {
+				Description: "Settings of the source as JSON-encoded object. Only the settings present in the object are managed, " +
+					"the other ones are left untouched",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+				StateFunc:    normalizedJson,
+			},
+			"force_destroy": {
+				Description: "Whether to delete the destinations, filters and schema config of the source and its tracking plan connections together with it. " +
+					"Otherwise, the deletion fails as long as the source has destinations",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"deletion_protection": deletionProtectionSchema("source"),
+			"catalog_id": {
+				Description: "ID of the catalog entry of the source",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"write_keys": {
+				Description: "Write keys of the source",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Sensitive:   true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"create_time": {
+				Description: "Time at which the source was created, in RFC 3339 format",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
 		},
 		CreateContext: resourceSegmentSourceCreate,
 		ReadContext:   resourceSegmentSourceRead,
+		UpdateContext: resourceSegmentSourceUpdate,
 		DeleteContext: resourceSegmentSourceDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceSegmentSourceImport,
 		},
+		CustomizeDiff: customdiff.Sequence(
+			customizeDiffValidateSourceCatalogName,
+			customizeDiffCheckSourceReplacement,
+		),
 	}
 }
 
 func resourceSegmentSourceCreate(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*segment.Client)
+	client := meta.(*Client)
 
 	slug := r.Get("slug").(string)
 	catName := r.Get("catalog_name").(string)
 
-	source, err := client.CreateSource(slug, catName)
+	source, err := client.CreateSource(c, slug, catName)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiag(err, "cannot create source %q", slug)
 	}
 
 	r.SetId(source.Name)
 
+	// the mutable properties cannot be set while creating the source
+	if diags := updateSegmentSource(c, r, client, slug); diags.HasError() {
+		return diags
+	}
+
 	return resourceSegmentSourceRead(c, r, meta)
 }
 
 func resourceSegmentSourceRead(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*segment.Client)
+	client := meta.(*Client)
 
-	name := r.Id()
-	slug := SourceNameToSlug(name)
+	slug, err := SourceNameToSlug(client.Workspace, r.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	s, err := client.GetSource(slug)
+	s, err := client.GetSource(c, slug)
 	if err != nil {
-		if IsNotFoundErr(err) {
+		switch ErrorKindOf(err) {
+		case ErrorKindNotFound:
 			r.SetId("")
 			return nil
-		} else {
-			return diag.FromErr(err)
+		default:
+			return apiErrorDiag(err, "cannot read source %q", slug)
 		}
 	}
 
@@ -72,26 +151,411 @@ func resourceSegmentSourceRead(c context.Context, r *schema.ResourceData, meta i
 	if err := r.Set("catalog_name", s.CatalogName); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := r.Set("display_name", s.DisplayName); err != nil {
+		return diag.FromErr(err)
+	}
+	if s.Enabled != nil {
+		if err := r.Set("enabled", *s.Enabled); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := r.Set("labels", s.Labels); err != nil {
+		return diag.FromErr(err)
+	}
+	if v, ok := r.GetOk("settings"); ok {
+		settings, err := flattenSourceSettings(v.(string), s.Settings)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := r.Set("settings", settings); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := r.Set("catalog_id", s.CatalogId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := r.Set("write_keys", s.WriteKeys); err != nil {
+		return diag.FromErr(err)
+	}
+	createTime := ""
+	if !s.CreateTime.IsZero() {
+		createTime = s.CreateTime.Format(time.RFC3339)
+	}
+	if err := r.Set("create_time", createTime); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
 
-func resourceSegmentSourceDelete(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*segment.Client)
-	name := r.Id()
+func resourceSegmentSourceUpdate(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
 
-	err := client.DeleteSource(SourceNameToSlug(name))
+	slug, err := SourceNameToSlug(client.Workspace, r.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if diags := updateSegmentSource(c, r, client, slug); diags.HasError() {
+		return diags
+	}
+
+	return resourceSegmentSourceRead(c, r, meta)
+}
+
+// updateSegmentSource sends the mutable properties of the source which differ from the state to Segment
+func updateSegmentSource(c context.Context, r *schema.ResourceData, client *Client, slug string) diag.Diagnostics {
+	var source Source
+	paths := make([]string, 0)
+
+	if r.HasChange("display_name") {
+		source.DisplayName = r.Get("display_name").(string)
+		paths = append(paths, "display_name")
+	}
+	if r.HasChange("enabled") {
+		enabled := r.Get("enabled").(bool)
+		source.Enabled = &enabled
+		paths = append(paths, "enabled")
+	}
+	if r.HasChange("labels") {
+		source.Labels = make(map[string]string)
+		for k, v := range r.Get("labels").(map[string]interface{}) {
+			source.Labels[k] = v.(string)
+		}
+		paths = append(paths, "labels")
+	}
+	if r.HasChange("settings") {
+		// settings are replaced as a whole, so the ones not managed by Terraform have to be sent as well
+		current, err := client.GetSource(c, slug)
+		if err != nil {
+			return apiErrorDiag(err, "cannot read settings of source %q", slug)
+		}
+		settings, err := expandSourceSettings(r.Get("settings").(string), current.Settings)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		source.Settings = settings
+		paths = append(paths, "settings")
+	}
+
+	if len(paths) == 0 {
+		return nil
+	}
+	if _, err := client.UpdateSource(c, slug, source, paths); err != nil {
+		return apiErrorDiag(err, "cannot update source %q", slug)
+	}
 	return nil
 }
 
-func SourceSlugToName(workspace, slug string) string {
-	return fmt.Sprintf("workspaces/%s/sources/%s", workspace, slug)
+func resourceSegmentSourceDelete(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	slug, err := SourceNameToSlug(client.Workspace, r.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if diags := checkDeletionProtection(r, "source", slug); diags.HasError() {
+		return diags
+	}
+
+	forceDestroy := r.Get("force_destroy").(bool)
+	dependents, err := findSourceDependents(c, client, slug, forceDestroy)
+	if err != nil {
+		if ErrorKindOf(err) == ErrorKindNotFound {
+			return nil
+		}
+		return apiErrorDiag(err, "cannot list dependents of source %q", slug)
+	}
+
+	var diags diag.Diagnostics
+	if !dependents.empty() {
+		if !forceDestroy {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("cannot delete source %q: it still has dependents", slug),
+				Detail: fmt.Sprintf("%s\n\nDelete them first or set \"force_destroy\" to true to delete them together with the source.",
+					dependents),
+			}}
+		}
+		if diags = deleteSourceDependents(c, client, slug, dependents); diags.HasError() {
+			return diags
+		}
+	}
+
+	err = client.DeleteSource(c, slug)
+	if err != nil && ErrorKindOf(err) != ErrorKindNotFound {
+		return append(diags, apiErrorDiag(err, "cannot delete source %q", slug)...)
+	}
+
+	return diags
+}
+
+func resourceSegmentSourceImport(c context.Context, r *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client)
+
+	slug, err := ParseSourceImportId(client.Workspace, r.Id())
+	if err != nil {
+		return nil, err
+	}
+	r.SetId(SourceSlugToName(client.Workspace, slug))
+	if err := r.Set("force_destroy", false); err != nil {
+		return nil, err
+	}
+	if err := r.Set("deletion_protection", false); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{r}, nil
+}
+
+// sourceDependents are the objects which have to be deleted before a source
+type sourceDependents struct {
+	// slugs of the destinations of the source
+	destinations []string
+	// whether the schema config of the source differs from the default one
+	schemaConfig bool
+	// IDs of the tracking plans connected to the source
+	trackingPlans []string
 }
 
-func SourceNameToSlug(name string) string {
-	return strings.Split(name, "/")[3]
+func (d sourceDependents) empty() bool {
+	return len(d.destinations) == 0 && !d.schemaConfig && len(d.trackingPlans) == 0
+}
+
+func (d sourceDependents) String() string {
+	var b strings.Builder
+	for _, dst := range d.destinations {
+		b.WriteString(fmt.Sprintf("- destination %q\n", dst))
+	}
+	if d.schemaConfig {
+		b.WriteString("- schema config\n")
+	}
+	for _, planId := range d.trackingPlans {
+		b.WriteString(fmt.Sprintf("- connection to tracking plan %q\n", planId))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// findSourceDependents returns the destinations of a source. With force_destroy, its schema config and
+// the tracking plans connected to it are looked up as well, to be deleted together with the source;
+// otherwise they do not prevent the deletion, which spares a request per tracking plan.
+func findSourceDependents(c context.Context, client *Client, slug string, forceDestroy bool) (sourceDependents, error) {
+	var dependents sourceDependents
+
+	destinations, err := client.ListDestinations(c, slug)
+	if err != nil {
+		return dependents, err
+	}
+	for _, d := range destinations.Destinations {
+		_, dstSlug, err := DestinationNameToSlugs(client.Workspace, d.Name)
+		if err != nil {
+			return dependents, err
+		}
+		dependents.destinations = append(dependents.destinations, dstSlug)
+	}
+	if !forceDestroy {
+		return dependents, nil
+	}
+
+	config, err := client.GetSourceConfig(c, slug)
+	switch {
+	case err == nil:
+		dependents.schemaConfig = config != DefaultSegmentSourceSchemaConfig
+	// the Segment API returns a 500 instead of a 404 for missing schema configs
+	case !Is500NilDereferenceErr(err):
+		return dependents, err
+	}
+
+	plans, err := client.ListTrackingPlans(c)
+	if err != nil {
+		return dependents, err
+	}
+	for _, p := range plans.TrackingPlans {
+		planId, err := TrackingPlanNameToId(client.Workspace, p.Name)
+		if err != nil {
+			return dependents, err
+		}
+		ok, err := FindTrackingPlanSourceConnection(c, client, planId, slug)
+		if err != nil {
+			return dependents, err
+		}
+		if ok {
+			dependents.trackingPlans = append(dependents.trackingPlans, planId)
+		}
+	}
+
+	return dependents, nil
+}
+
+// deleteSourceDependents deletes the filters and destinations of a source, resets its schema config
+// and disconnects it from tracking plans. It returns a warning listing what has been deleted.
+func deleteSourceDependents(c context.Context, client *Client, slug string, dependents sourceDependents) diag.Diagnostics {
+	deleted := make([]string, 0)
+	warning := func() diag.Diagnostics {
+		if len(deleted) == 0 {
+			return nil
+		}
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("deleted dependents of source %q", slug),
+			Detail:   strings.Join(deleted, "\n"),
+		}}
+	}
+
+	for _, dstSlug := range dependents.destinations {
+		filters, err := client.ListDestinationFilters(c, slug, dstSlug)
+		if err != nil && ErrorKindOf(err) != ErrorKindNotFound {
+			return append(warning(), apiErrorDiag(err, "cannot list filters of destination %q of source %q", dstSlug, slug)...)
+		}
+		for _, f := range filters {
+			_, _, filterId, err := DestinationFilterNameToIds(client.Workspace, f.Name)
+			if err != nil {
+				return append(warning(), diag.FromErr(err)...)
+			}
+			err = client.DeleteDestinationFilter(c, slug, dstSlug, filterId)
+			if err != nil && ErrorKindOf(err) != ErrorKindNotFound {
+				return append(warning(), apiErrorDiag(err, "cannot delete filter %q of destination %q of source %q", filterId, dstSlug, slug)...)
+			}
+			deleted = append(deleted, fmt.Sprintf("- filter %q of destination %q", filterId, dstSlug))
+		}
+
+		err = client.DeleteDestination(c, slug, dstSlug)
+		if err != nil && ErrorKindOf(err) != ErrorKindNotFound {
+			return append(warning(), apiErrorDiag(err, "cannot delete destination %q of source %q", dstSlug, slug)...)
+		}
+		deleted = append(deleted, fmt.Sprintf("- destination %q", dstSlug))
+	}
+
+	if dependents.schemaConfig {
+		_, err := client.UpdateSourceConfig(c, slug, DefaultSegmentSourceSchemaConfig)
+		if err != nil && !(IsNotFoundErr(err) || Is500NilDereferenceErr(err)) {
+			return append(warning(), apiErrorDiag(err, "cannot reset schema config of source %q", slug)...)
+		}
+		deleted = append(deleted, "- schema config, reset to the default one")
+	}
+
+	for _, planId := range dependents.trackingPlans {
+		err := client.DeleteTrackingPlanSourceConnection(c, planId, slug)
+		if err != nil && ErrorKindOf(err) != ErrorKindNotFound {
+			return append(warning(), apiErrorDiag(err, "cannot disconnect source %q from tracking plan %q", slug, planId)...)
+		}
+		deleted = append(deleted, fmt.Sprintf("- connection to tracking plan %q", planId))
+	}
+
+	return warning()
+}
+
+// expandSourceSettings merges the settings declared as JSON object into the current settings of the source
+func expandSourceSettings(declared string, current map[string]interface{}) (map[string]interface{}, error) {
+	settings := make(map[string]interface{})
+	for k, v := range current {
+		settings[k] = v
+	}
+	if declared == "" {
+		return settings, nil
+	}
+	var d map[string]interface{}
+	if err := json.Unmarshal([]byte(declared), &d); err != nil {
+		return nil, fmt.Errorf("invalid source settings: %w", err)
+	}
+	for k, v := range d {
+		settings[k] = v
+	}
+	return settings, nil
+}
+
+// flattenSourceSettings returns the actual values of the settings declared as JSON object
+func flattenSourceSettings(declared string, actual map[string]interface{}) (string, error) {
+	var d map[string]interface{}
+	if err := json.Unmarshal([]byte(declared), &d); err != nil {
+		return "", fmt.Errorf("invalid source settings: %w", err)
+	}
+	settings := make(map[string]interface{})
+	for k := range d {
+		if v, ok := actual[k]; ok {
+			settings[k] = v
+		}
+	}
+	j, err := json.Marshal(settings)
+	if err != nil {
+		return "", fmt.Errorf("cannot flatten source settings: %w", err)
+	}
+	return string(j), nil
+}
+
+func normalizedJson(v interface{}) string {
+	j, _ := structure.NormalizeJsonString(v)
+	return j
+}
+
+// customizeDiffValidateSourceCatalogName checks that catalog_name exists in the source catalog,
+// so that a typo is reported during plan, together with the closest existing names
+func customizeDiffValidateSourceCatalogName(c context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("catalog_name") || (diff.Id() != "" && !diff.HasChange("catalog_name")) {
+		return nil
+	}
+	client := meta.(*Client)
+	catName := diff.Get("catalog_name").(string)
+
+	catalog, err := client.ListCatalogSources(c)
+	if err != nil {
+		if isTransientErr(err) {
+			// the validation is a convenience only, the API rejects invalid names anyway
+			log.Printf("[WARN] cannot validate catalog_name %q of source: %s", catName, err)
+			return nil
+		}
+		return fmt.Errorf("cannot validate catalog_name %q against the source catalog: %w", catName, err)
+	}
+	if len(catalog) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(catalog))
+	for _, s := range catalog {
+		if s.Name == catName {
+			return nil
+		}
+		names = append(names, strings.TrimPrefix(s.Name, catalogSourcesPath+"/"))
+	}
+
+	suggestions := closestMatches(strings.TrimPrefix(catName, catalogSourcesPath+"/"), names, 3)
+	if len(suggestions) == 0 {
+		return fmt.Errorf("catalog_name %q does not exist in the source catalog", catName)
+	}
+	for i, name := range suggestions {
+		suggestions[i] = fmt.Sprintf("%q", fmt.Sprintf("%s/%s", catalogSourcesPath, name))
+	}
+	return fmt.Errorf("catalog_name %q does not exist in the source catalog, did you mean %s?", catName, strings.Join(suggestions, " or "))
+}
+
+// customizeDiffCheckSourceReplacement refuses to replace a source whose slug does not change while it has destinations.
+// They stay in the configuration and are not replaced with it, so deleting the source would either fail or,
+// with force_destroy, delete them regardless of their deletion protection.
+func customizeDiffCheckSourceReplacement(c context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("catalog_name") || diff.HasChange("slug") {
+		return nil
+	}
+	client := meta.(*Client)
+	slug, _ := diff.GetChange("slug")
+
+	destinations, err := client.ListDestinations(c, slug.(string))
+	if err != nil {
+		if ErrorKindOf(err) == ErrorKindNotFound {
+			return nil
+		}
+		return fmt.Errorf("cannot list destinations of source %q: %w", slug, err)
+	}
+	if len(destinations.Destinations) == 0 {
+		return nil
+	}
+
+	slugs := make([]string, 0, len(destinations.Destinations))
+	for _, d := range destinations.Destinations {
+		_, dstSlug, err := DestinationNameToSlugs(client.Workspace, d.Name)
+		if err != nil {
+			return err
+		}
+		slugs = append(slugs, dstSlug)
+	}
+	return fmt.Errorf("cannot replace source %q to change its catalog_name while it has destinations (%s), delete them first",
+		slug, strings.Join(slugs, ", "))
 }
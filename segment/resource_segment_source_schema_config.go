@@ -84,24 +84,30 @@ func resourceSegmentSourceSchemaConfig() *schema.Resource {
 				Default:  DefaultSourceSchemaConfig["common_group_event_on_violations"].(string),
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 		CreateContext: resourceSegmentSourceSchemaConfigCreate,
 		ReadContext:   resourceSegmentSourceSchemaConfigRead,
 		UpdateContext: resourceSegmentSourceSchemaConfigCreate,
 		DeleteContext: resourceSegmentSourceSchemaConfigDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceSegmentSourceSchemaConfigImport,
 		},
 	}
 }
 
 func resourceSegmentSourceSchemaConfigCreate(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*segment.Client)
+	client := meta.(*Client)
 
 	srcSlug := r.Get("source_slug").(string)
 
-	configBefore, err := client.GetSourceConfig(srcSlug)
+	configBefore, err := client.GetSourceConfig(c, srcSlug)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiag(err, "cannot read schema config of source %q", srcSlug)
 	}
 
 	config := segment.SourceConfig{
@@ -120,34 +126,38 @@ func resourceSegmentSourceSchemaConfigCreate(c context.Context, r *schema.Resour
 		CommonGroupEventOnViolations:        segment.CommonEventSettings(r.Get("common_group_event_on_violations").(string)),
 	}
 
-	sourceConfig, err := client.UpdateSourceConfig(srcSlug, config)
+	sourceConfig, err := client.UpdateSourceConfig(c, srcSlug, config)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiag(err, "cannot update schema config of source %q", srcSlug)
 	}
 
 	r.SetId(sourceConfig.Name)
 
-	err = waitUntilSourceSchemaConfigModified(client, srcSlug, configBefore)
+	err = waitUntilSourceSchemaConfigModified(c, client, srcSlug, configBefore)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiag(err, "cannot confirm update of schema config of source %q", srcSlug)
 	}
 
 	return resourceSegmentSourceSchemaConfigRead(c, r, meta)
 }
 
 func resourceSegmentSourceSchemaConfigRead(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*segment.Client)
+	client := meta.(*Client)
 
-	name := r.Id()
-	srcSlug := SourceNameToSlug(name)
+	srcSlug, err := SourceSchemaConfigNameToSourceSlug(client.Workspace, r.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	s, err := client.GetSourceConfig(srcSlug)
+	s, err := client.GetSourceConfig(c, srcSlug)
 	if err != nil {
-		if IsNotFoundErr(err) || Is500NilDereferenceErr(err) {
+		switch {
+		// the Segment API returns a 500 instead of a 404 for missing schema configs
+		case IsNotFoundErr(err) || Is500NilDereferenceErr(err):
 			r.SetId("")
 			return nil
-		} else {
-			return diag.FromErr(err)
+		default:
+			return apiErrorDiag(err, "cannot read schema config of source %q", srcSlug)
 		}
 	}
 
@@ -198,15 +208,17 @@ func resourceSegmentSourceSchemaConfigRead(c context.Context, r *schema.Resource
 }
 
 func resourceSegmentSourceSchemaConfigDelete(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*segment.Client)
+	client := meta.(*Client)
 
-	name := r.Id()
-	srcSlug := SourceNameToSlug(name)
+	srcSlug, err := SourceSchemaConfigNameToSourceSlug(client.Workspace, r.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	config := DefaultSegmentSourceSchemaConfig
 
-	_, err := client.UpdateSourceConfig(srcSlug, config)
+	_, err = client.UpdateSourceConfig(c, srcSlug, config)
 	if err != nil && !(IsNotFoundErr(err) || Is500NilDereferenceErr(err)) {
-		return diag.FromErr(err)
+		return apiErrorDiag(err, "cannot reset schema config of source %q", srcSlug)
 	}
 	return nil
 }
@@ -243,25 +255,35 @@ var DefaultSegmentSourceSchemaConfig = segment.SourceConfig{
 	CommonGroupEventOnViolations:        segment.CommonEventSettings(DefaultSourceSchemaConfig["common_group_event_on_violations"].(string)),
 }
 
-func waitUntilSourceSchemaConfigModified(client *segment.Client, srcSlug string, configBefore segment.SourceConfig) error {
-	c := make(chan error, 1)
-	go func() {
-		for {
-			s, err := client.GetSourceConfig(srcSlug)
-			if err != nil {
-				c <- err
-				break
-			} else if s != configBefore {
-				c <- nil
-				break
+// waitUntilSourceSchemaConfigModified polls the schema config until the update is visible,
+// for at most as long as the create or update timeout of the resource allows.
+func waitUntilSourceSchemaConfigModified(c context.Context, client *Client, srcSlug string, configBefore segment.SourceConfig) error {
+	for {
+		s, err := client.GetSourceConfig(c, srcSlug)
+		if err != nil {
+			if c.Err() != nil {
+				return fmt.Errorf("stopped waiting for source schema %q to be modified: %w", srcSlug, c.Err())
 			}
-			time.Sleep(1 * time.Second)
+			return err
+		} else if s != configBefore {
+			return nil
+		}
+		select {
+		case <-c.Done():
+			return fmt.Errorf("stopped waiting for source schema %q to be modified: %w", srcSlug, c.Err())
+		case <-time.After(1 * time.Second):
 		}
-	}()
-	select {
-	case err := <-c:
-		return err
-	case <-time.After(1 * time.Minute):
-		return fmt.Errorf("timeout after waiting for source schema %q to be modified", srcSlug)
 	}
 }
+
+func resourceSegmentSourceSchemaConfigImport(c context.Context, r *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client)
+
+	srcSlug, err := ParseSourceSchemaConfigImportId(client.Workspace, r.Id())
+	if err != nil {
+		return nil, err
+	}
+	r.SetId(SourceSchemaConfigSlugToName(client.Workspace, srcSlug))
+
+	return []*schema.ResourceData{r}, nil
+}
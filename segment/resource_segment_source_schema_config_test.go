@@ -1,14 +1,18 @@
 package segment_test
 
 import (
+	"context"
 	"fmt"
 	segmentapi "github.com/forteilgmbh/segment-config-go/segment"
 	"github.com/forteilgmbh/terraform-provider-segment/segment"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"net/http"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestAccSegmentSourceSchemaConfig_basic(t *testing.T) {
@@ -75,14 +79,19 @@ func TestAccSegmentSourceSchemaConfig_disappears(t *testing.T) {
 }
 
 func testAccCheckSegmentSourceSchemaConfigDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*segmentapi.Client)
+	client := testAccProvider.Meta().(*segment.Client)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "segment_source_schema_config" {
 			continue
 		}
 
-		c, err := client.GetSourceConfig(segment.SourceNameToSlug(rs.Primary.ID))
+		srcSlug, err := segment.SourceSchemaConfigNameToSourceSlug(client.Workspace, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		c, err := client.GetSourceConfig(context.Background(), srcSlug)
 
 		if err == nil {
 			if c == segment.DefaultSegmentSourceSchemaConfig {
@@ -112,9 +121,14 @@ func testAccCheckSourceSchemaConfigExists(name string, schemaConfig *segmentapi.
 			return fmt.Errorf("source schema config %q has no ID set", name)
 		}
 
-		client := testAccProvider.Meta().(*segmentapi.Client)
+		client := testAccProvider.Meta().(*segment.Client)
 
-		resp, err := client.GetSourceConfig(segment.SourceNameToSlug(rs.Primary.ID))
+		srcSlug, err := segment.SourceSchemaConfigNameToSourceSlug(client.Workspace, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.GetSourceConfig(context.Background(), srcSlug)
 		if err != nil {
 			return err
 		}
@@ -126,9 +140,12 @@ func testAccCheckSourceSchemaConfigExists(name string, schemaConfig *segmentapi.
 
 func testAccCheckSourceSchemaConfigDisappears(schemaConfig *segmentapi.SourceConfig) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*segmentapi.Client)
-		err := client.DeleteSource(segment.SourceNameToSlug(schemaConfig.Name)) // not a mistake - we want to check the case when entire source is deleted
-		return err
+		client := testAccProvider.Meta().(*segment.Client)
+		srcSlug, err := segment.SourceSchemaConfigNameToSourceSlug(client.Workspace, schemaConfig.Name)
+		if err != nil {
+			return err
+		}
+		return client.DeleteSource(context.Background(), srcSlug) // not a mistake - we want to check the case when entire source is deleted
 	}
 }
 
@@ -218,3 +235,53 @@ resource "segment_source" "violations_destination" {
 `, flag, !flag, srcViolationsSlug),
 	)
 }
+
+func TestSourceSchemaConfig_createTimeout(t *testing.T) {
+	cases := map[string]http.HandlerFunc{
+		// the update is never visible, so waitUntilSourceSchemaConfigModified keeps polling
+		"wait": func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"name": "workspaces/myworkspace/sources/ios/schema-config"}`)
+		},
+		// requests hang
+		"request": func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Minute):
+			}
+		},
+		// requests are rate limited for longer than the timeout
+		"retry": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+		},
+	}
+
+	for name, handler := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := newTestClient(t, handler, segment.ClientConfig{MaxRetries: 10, RetryMaxWait: time.Minute})
+			res := segment.Provider().ResourcesMap["segment_source_schema_config"]
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"source_slug": "ios",
+				"timeouts": map[string]interface{}{
+					"create": "2s",
+				},
+			})
+			diff, err := res.Diff(context.Background(), nil, config, client)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			start := time.Now()
+			_, diags := res.Apply(context.Background(), nil, diff, client)
+			if !diags.HasError() {
+				t.Fatal("expected error")
+			}
+			if detail := diags[0].Summary + diags[0].Detail; !strings.Contains(detail, "deadline exceeded") {
+				t.Errorf("expected the timeout to be reported, got: %s", detail)
+			}
+			if elapsed := time.Since(start); elapsed > 10*time.Second {
+				t.Errorf("create took %s despite a timeout of 2s", elapsed)
+			}
+		})
+	}
+}
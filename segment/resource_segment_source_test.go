@@ -1,18 +1,22 @@
 package segment_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	segmentapi "github.com/forteilgmbh/segment-config-go/segment"
 	"github.com/forteilgmbh/terraform-provider-segment/segment"
+	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"net/http"
 	"regexp"
+	"strings"
 	"testing"
 )
 
 func TestAccSegmentSource_basic(t *testing.T) {
-	var source segmentapi.Source
+	var source segment.Source
 	srcSlug := acctest.RandomWithPrefix("tf-testacc-src-basic")
 	catalogName := "catalog/sources/net"
 	sourceNameRegexp, _ := regexp.Compile("^workspaces/[a-z0-9._-]+/sources/[a-z0-9._-]+$")
@@ -29,6 +33,7 @@ func TestAccSegmentSource_basic(t *testing.T) {
 					resource.TestMatchResourceAttr("segment_source.test", "id", sourceNameRegexp),
 					resource.TestCheckResourceAttr("segment_source.test", "slug", srcSlug),
 					resource.TestCheckResourceAttr("segment_source.test", "catalog_name", catalogName),
+					resource.TestCheckResourceAttr("segment_source.test", "write_keys.#", "1"),
 					testAccCheckSourceAttributes_basic(&source, srcSlug, catalogName),
 				),
 			},
@@ -37,12 +42,148 @@ func TestAccSegmentSource_basic(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:      "segment_source.test",
+				ImportState:       true,
+				ImportStateId:     srcSlug,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccSegmentSource_update(t *testing.T) {
+	var sourceBefore, sourceAfter segment.Source
+	srcSlug := acctest.RandomWithPrefix("tf-testacc-src-update")
+	catalogName := "catalog/sources/net"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckSegmentSourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSegmentSourceConfig_update(srcSlug, catalogName, "Before", true, "dev"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSourceExists("segment_source.test", &sourceBefore),
+					resource.TestCheckResourceAttr("segment_source.test", "display_name", "Before"),
+					resource.TestCheckResourceAttr("segment_source.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("segment_source.test", "labels.%", "1"),
+					resource.TestCheckResourceAttr("segment_source.test", "labels.environment", "dev"),
+					resource.TestCheckResourceAttrSet("segment_source.test", "catalog_id"),
+					resource.TestCheckResourceAttrSet("segment_source.test", "create_time"),
+				),
+			},
+			{
+				Config: testAccSegmentSourceConfig_update(srcSlug, catalogName, "After", false, "prod"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSourceExists("segment_source.test", &sourceAfter),
+					testAccCheckSourceNotRecreated(&sourceBefore, &sourceAfter),
+					resource.TestCheckResourceAttr("segment_source.test", "display_name", "After"),
+					resource.TestCheckResourceAttr("segment_source.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("segment_source.test", "labels.environment", "prod"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSegmentSource_forceDestroy(t *testing.T) {
+	var source segment.Source
+	srcSlug := acctest.RandomWithPrefix("tf-testacc-src-force")
+	catalogName := "catalog/sources/net"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckSegmentSourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSegmentSourceConfig_forceDestroy(srcSlug, catalogName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSourceExists("segment_source.test", &source),
+					resource.TestCheckResourceAttr("segment_source.test", "force_destroy", "false"),
+					testAccCreateSourceDestination(srcSlug, "webhooks"),
+				),
+			},
+			// the destination created outside of Terraform prevents the deletion
+			{
+				Config:      testAccSegmentSourceConfig_forceDestroy(srcSlug, catalogName, false),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("still has dependents"),
+			},
+			// unless force_destroy is set, which does not require recreating the source:
+			// the destination and the tracking plan connection are then removed when destroyed at the end of the test
+			{
+				Config: testAccSegmentSourceConfig_forceDestroy(srcSlug, catalogName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSourceExists("segment_source.test", &source),
+					resource.TestCheckResourceAttr("segment_source.test", "force_destroy", "true"),
+					testAccConnectSourceTrackingPlan(srcSlug, "segment_tracking_plan.test"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSegmentSource_deletionProtection(t *testing.T) {
+	var sourceBefore, sourceAfter segment.Source
+	srcSlug := acctest.RandomWithPrefix("tf-testacc-src-protected")
+	catalogName := "catalog/sources/net"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckSegmentSourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSegmentSourceConfig_deletionProtection(srcSlug, catalogName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSourceExists("segment_source.test", &sourceBefore),
+					resource.TestCheckResourceAttr("segment_source.test", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      testAccSegmentSourceConfig_deletionProtection(srcSlug, catalogName, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("deletion protection is enabled"),
+			},
+			// replacing the source is prevented as well
+			{
+				Config:      testAccSegmentSourceConfig_deletionProtection(srcSlug+"-renamed", catalogName, true),
+				ExpectError: regexp.MustCompile("deletion protection is enabled"),
+			},
+			// turning it off does not modify the source
+			{
+				Config: testAccSegmentSourceConfig_deletionProtection(srcSlug, catalogName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSourceExists("segment_source.test", &sourceAfter),
+					testAccCheckSourceNotRecreated(&sourceBefore, &sourceAfter),
+					resource.TestCheckResourceAttr("segment_source.test", "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSegmentSource_invalidCatalogName(t *testing.T) {
+	srcSlug := acctest.RandomWithPrefix("tf-testacc-src-catalog")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSegmentSourceConfig_basic(srcSlug, "catalog/sources/javascrpt"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`did you mean "catalog/sources/javascript"`),
+			},
 		},
 	})
 }
 
 func TestAccSegmentSource_disappears(t *testing.T) {
-	var source segmentapi.Source
+	var source segment.Source
 	srcSlug := acctest.RandomWithPrefix("tf-testacc-src-disappears")
 	catalogName := "catalog/sources/net"
 
@@ -64,14 +205,19 @@ func TestAccSegmentSource_disappears(t *testing.T) {
 }
 
 func testAccCheckSegmentSourceDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*segmentapi.Client)
+	client := testAccProvider.Meta().(*segment.Client)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "segment_source" {
 			continue
 		}
 
-		_, err := client.GetSource(segment.SourceNameToSlug(rs.Primary.ID))
+		slug, err := segment.SourceNameToSlug(client.Workspace, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.GetSource(context.Background(), slug)
 
 		if err == nil {
 			return fmt.Errorf("source %q still exists", rs.Primary.ID)
@@ -85,7 +231,7 @@ func testAccCheckSegmentSourceDestroy(s *terraform.State) error {
 	return nil
 }
 
-func testAccCheckSourceExists(name string, source *segmentapi.Source) resource.TestCheckFunc {
+func testAccCheckSourceExists(name string, source *segment.Source) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
 		if !ok {
@@ -95,9 +241,14 @@ func testAccCheckSourceExists(name string, source *segmentapi.Source) resource.T
 			return fmt.Errorf("source %q has no ID set", name)
 		}
 
-		client := testAccProvider.Meta().(*segmentapi.Client)
+		client := testAccProvider.Meta().(*segment.Client)
+
+		slug, err := segment.SourceNameToSlug(client.Workspace, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
 
-		resp, err := client.GetSource(segment.SourceNameToSlug(rs.Primary.ID))
+		resp, err := client.GetSource(context.Background(), slug)
 		if err != nil {
 			return err
 		}
@@ -107,17 +258,21 @@ func testAccCheckSourceExists(name string, source *segmentapi.Source) resource.T
 	}
 }
 
-func testAccCheckSourceDisappears(source *segmentapi.Source) resource.TestCheckFunc {
+func testAccCheckSourceDisappears(source *segment.Source) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*segmentapi.Client)
-		err := client.DeleteSource(segment.SourceNameToSlug(source.Name))
-		return err
+		client := testAccProvider.Meta().(*segment.Client)
+		slug, err := segment.SourceNameToSlug(client.Workspace, source.Name)
+		if err != nil {
+			return err
+		}
+
+		return client.DeleteSource(context.Background(), slug)
 	}
 }
 
-func testAccCheckSourceAttributes_basic(source *segmentapi.Source, srcSlug string, catalogName string) resource.TestCheckFunc {
+func testAccCheckSourceAttributes_basic(source *segment.Source, srcSlug string, catalogName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*segmentapi.Client)
+		client := testAccProvider.Meta().(*segment.Client)
 
 		if source.Name != segment.SourceSlugToName(client.Workspace, srcSlug) {
 			return fmt.Errorf("invalid source.Name: expected: %q, actual: %q", segment.SourceSlugToName(client.Workspace, srcSlug), source.Name)
@@ -125,10 +280,44 @@ func testAccCheckSourceAttributes_basic(source *segmentapi.Source, srcSlug strin
 		if source.CatalogName != catalogName {
 			return fmt.Errorf("invalid source.CatalogName: expected: %q, actual: %q", catalogName, source.CatalogName)
 		}
+		rs := s.RootModule().Resources["segment_source.test"]
+		for i, key := range source.WriteKeys {
+			if actual := rs.Primary.Attributes[fmt.Sprintf("write_keys.%d", i)]; actual != key {
+				return fmt.Errorf("invalid write_keys.%d: expected: %q, actual: %q", i, key, actual)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckSourceNotRecreated(before, after *segment.Source) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if !before.CreateTime.Equal(after.CreateTime) {
+			return fmt.Errorf("source %q was recreated", after.Name)
+		}
 		return nil
 	}
 }
 
+func testAccCreateSourceDestination(srcSlug, dstSlug string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*segment.Client)
+		_, err := client.CreateDestination(context.Background(), srcSlug, dstSlug, "UNSPECIFIED", false, nil)
+		return err
+	}
+}
+
+func testAccConnectSourceTrackingPlan(srcSlug, planResourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[planResourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", planResourceName)
+		}
+		client := testAccProvider.Meta().(*segment.Client)
+		return client.CreateTrackingPlanSourceConnection(context.Background(), rs.Primary.ID, srcSlug)
+	}
+}
+
 func testAccSegmentSourceConfig_basic(srcName, catalogName string) string {
 	return fmt.Sprintf(`
 resource "segment_source" "test" {
@@ -137,3 +326,167 @@ resource "segment_source" "test" {
 }
 `, srcName, catalogName)
 }
+
+func testAccSegmentSourceConfig_update(srcName, catalogName, displayName string, enabled bool, environment string) string {
+	return fmt.Sprintf(`
+resource "segment_source" "test" {
+  slug         = %q
+  catalog_name = %q
+  display_name = %q
+  enabled      = %t
+
+  labels = {
+    environment = %q
+  }
+}
+`, srcName, catalogName, displayName, enabled, environment)
+}
+
+func testAccSegmentSourceConfig_forceDestroy(srcName, catalogName string, forceDestroy bool) string {
+	return fmt.Sprintf(`
+resource "segment_tracking_plan" "test" {
+  display_name = %q
+}
+
+resource "segment_source" "test" {
+  slug          = %q
+  catalog_name  = %q
+  force_destroy = %t
+
+  # destroyed before the tracking plan, so that the connection to it is still there
+  depends_on = [segment_tracking_plan.test]
+}
+`, srcName, srcName, catalogName, forceDestroy)
+}
+
+func testAccSegmentSourceConfig_deletionProtection(srcName, catalogName string, deletionProtection bool) string {
+	return fmt.Sprintf(`
+resource "segment_source" "test" {
+  slug                = %q
+  catalog_name        = %q
+  deletion_protection = %t
+}
+`, srcName, catalogName, deletionProtection)
+}
+
+func TestSegmentSource_importWithoutEnabled(t *testing.T) {
+	res, client := newTestResource(t, "segment_source", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected %s request to %s", r.Method, r.URL.Path)
+		}
+		// sources created before "enabled" was managed, as returned by an API which omits the flag
+		fmt.Fprint(w, `{"name": "workspaces/myworkspace/sources/ios", "catalog_name": "catalog/sources/ios"}`)
+	})
+	ctx := context.Background()
+
+	imported, err := res.Importer.StateContext(ctx, res.Data(&terraform.InstanceState{ID: "ios"}), client)
+	if err != nil {
+		t.Fatalf("unexpected import error: %s", err)
+	}
+	state, diags := res.RefreshWithoutUpgrade(ctx, imported[0].State(), client)
+	if diags.HasError() {
+		t.Fatalf("unexpected refresh error: %v", diags)
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"slug":         "ios",
+		"catalog_name": "catalog/sources/ios",
+	})
+	diff, err := res.Diff(ctx, state, config, client)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %s", err)
+	}
+	if !diff.Empty() {
+		t.Errorf("unexpected diff: %v", diff)
+	}
+}
+
+func TestSegmentSource_deleteWithDestinations(t *testing.T) {
+	schemaConfig, _ := json.Marshal(segment.DefaultSegmentSourceSchemaConfig)
+	responses := map[string]string{
+		"GET /v1beta/workspaces/myworkspace/sources/ios/destinations":                  `{"destinations": [{"name": "workspaces/myworkspace/sources/ios/destinations/webhooks"}]}`,
+		"GET /v1beta/workspaces/myworkspace/sources/ios/destinations/webhooks/filters": `{}`,
+		"GET /v1beta/workspaces/myworkspace/sources/ios/schema-config":                 string(schemaConfig),
+		"GET /v1beta/workspaces/myworkspace/tracking-plans":                            `{}`,
+	}
+	cases := []struct {
+		forceDestroy bool
+		expected     []string
+	}{
+		// the tracking plans are not scanned as the destination prevents the deletion anyway
+		{false, []string{
+			"GET /v1beta/workspaces/myworkspace/sources/ios/destinations",
+		}},
+		{true, []string{
+			"GET /v1beta/workspaces/myworkspace/sources/ios/destinations",
+			"GET /v1beta/workspaces/myworkspace/sources/ios/schema-config",
+			"GET /v1beta/workspaces/myworkspace/tracking-plans",
+			"GET /v1beta/workspaces/myworkspace/sources/ios/destinations/webhooks/filters",
+			"DELETE /v1beta/workspaces/myworkspace/sources/ios/destinations/webhooks",
+			"DELETE /v1beta/workspaces/myworkspace/sources/ios",
+		}},
+	}
+	for _, tc := range cases {
+		var requests []string
+		res, client := newTestResource(t, "segment_source", func(w http.ResponseWriter, r *http.Request) {
+			request := r.Method + " " + strings.TrimSuffix(r.URL.Path, "/")
+			requests = append(requests, request)
+			if response, ok := responses[request]; ok {
+				fmt.Fprint(w, response)
+			} else {
+				fmt.Fprint(w, `{}`)
+			}
+		})
+		state := &terraform.InstanceState{
+			ID: "workspaces/myworkspace/sources/ios",
+			Attributes: map[string]string{
+				"id":                  "workspaces/myworkspace/sources/ios",
+				"slug":                "ios",
+				"force_destroy":       fmt.Sprintf("%t", tc.forceDestroy),
+				"deletion_protection": "false",
+			},
+		}
+
+		_, diags := res.Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, client)
+		if tc.forceDestroy {
+			if diags.HasError() || len(diags) != 1 || !strings.Contains(diags[0].Detail, `destination "webhooks"`) {
+				t.Errorf("expected warning listing the deleted destination, actual: %v", diags)
+			}
+		} else if !diags.HasError() || !strings.Contains(diags[0].Summary, "still has dependents") {
+			t.Errorf("expected error about the dependents, actual: %v", diags)
+		}
+		if !cmp.Equal(requests, tc.expected) {
+			t.Errorf("invalid requests with force_destroy %t: %s", tc.forceDestroy, cmp.Diff(tc.expected, requests))
+		}
+	}
+}
+
+func TestSegmentSource_catalogError(t *testing.T) {
+	cases := []struct {
+		status   int
+		expected string
+	}{
+		// the validation is skipped on temporary failures
+		{http.StatusServiceUnavailable, ""},
+		{http.StatusTooManyRequests, ""},
+		{http.StatusForbidden, `cannot validate catalog_name "catalog/sources/ios" against the source catalog`},
+	}
+
+	for _, c := range cases {
+		res, client := newTestResource(t, "segment_source", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(c.status)
+		})
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"slug":         "ios",
+			"catalog_name": "catalog/sources/ios",
+		})
+
+		_, err := res.Diff(context.Background(), nil, config, client)
+		switch {
+		case c.expected == "" && err != nil:
+			t.Errorf("unexpected error for %d: %s", c.status, err)
+		case c.expected != "" && (err == nil || !strings.Contains(err.Error(), c.expected)):
+			t.Errorf("invalid error for %d: expected: %q, actual: %v", c.status, c.expected, err)
+		}
+	}
+}
@@ -52,19 +52,26 @@ func resourceSegmentTrackingPlan() *schema.Resource {
 					Type:      schema.TypeString,
 				},
 			},
+			"deletion_protection": deletionProtectionSchema("tracking plan"),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
 		},
 		CreateContext: resourceSegmentTrackingPlanCreate,
 		ReadContext:   resourceSegmentTrackingPlanRead,
 		DeleteContext: resourceSegmentTrackingPlanDelete,
 		UpdateContext: resourceSegmentTrackingPlanUpdate,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceSegmentTrackingPlanImport,
 		},
 	}
 }
 
 func resourceSegmentTrackingPlanCreate(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*segment.Client)
+	client := meta.(*Client)
 
 	displayName := r.Get("display_name").(string)
 
@@ -98,31 +105,40 @@ func resourceSegmentTrackingPlanCreate(c context.Context, r *schema.ResourceData
 		rules.Events = events
 	}
 
-	trackingPlan, err := client.CreateTrackingPlan(segment.TrackingPlan{DisplayName: displayName, Rules: *rules})
+	trackingPlan, err := client.CreateTrackingPlan(c, segment.TrackingPlan{DisplayName: displayName, Rules: *rules})
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiag(err, "cannot create tracking plan %q", displayName)
 	}
 
-	planId := TrackingPlanNameToId(trackingPlan.Name)
+	planId, err := TrackingPlanNameToId(client.Workspace, trackingPlan.Name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	r.SetId(planId)
 
 	return resourceSegmentTrackingPlanRead(c, r, meta)
 }
 
 func resourceSegmentTrackingPlanRead(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*segment.Client)
+	client := meta.(*Client)
 	planId := r.Id()
-	names, err := getTrackingPlansNames(client)
+	names, err := getTrackingPlansNames(c, client)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiag(err, "cannot read tracking plan %q", planId)
 	}
 	if _, ok := names[planId]; !ok {
 		r.SetId("")
 		return nil
 	}
-	trackingPlan, err := client.GetTrackingPlan(planId)
+	trackingPlan, err := client.GetTrackingPlan(c, planId)
 	if err != nil {
-		return diag.FromErr(err)
+		switch ErrorKindOf(err) {
+		case ErrorKindNotFound:
+			r.SetId("")
+			return nil
+		default:
+			return apiErrorDiag(err, "cannot read tracking plan %q", planId)
+		}
 	}
 
 	if err := r.Set("display_name", trackingPlan.DisplayName); err != nil {
@@ -161,30 +177,37 @@ func resourceSegmentTrackingPlanRead(c context.Context, r *schema.ResourceData,
 }
 
 func resourceSegmentTrackingPlanDelete(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*segment.Client)
+	client := meta.(*Client)
 	planId := r.Id()
-	err := client.DeleteTrackingPlan(planId)
-	if err != nil {
-		return diag.FromErr(err)
+	if diags := checkDeletionProtection(r, "tracking plan", planId); diags.HasError() {
+		return diags
+	}
+	err := client.DeleteTrackingPlan(c, planId)
+	if err != nil && ErrorKindOf(err) != ErrorKindNotFound {
+		return apiErrorDiag(err, "cannot delete tracking plan %q", planId)
 	}
 	return nil
 }
 
 func resourceSegmentTrackingPlanUpdate(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*segment.Client)
+	client := meta.(*Client)
 	planId := r.Id()
 	displayName := r.Get("display_name").(string)
 
-	names, err := getTrackingPlansNames(client)
+	if !r.HasChangesExcept("deletion_protection") {
+		return resourceSegmentTrackingPlanRead(c, r, meta)
+	}
+
+	names, err := getTrackingPlansNames(c, client)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiag(err, "cannot update tracking plan %q", planId)
 	}
 	if _, ok := names[planId]; !ok {
 		return diag.Errorf("plan no longer exists")
 	}
-	trackingPlan, err := client.GetTrackingPlan(planId)
+	trackingPlan, err := client.GetTrackingPlan(c, planId)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiag(err, "cannot update tracking plan %q", planId)
 	}
 	rules := trackingPlan.Rules
 
@@ -221,25 +244,24 @@ func resourceSegmentTrackingPlanUpdate(c context.Context, r *schema.ResourceData
 		DisplayName: displayName,
 		Rules:       rules,
 	}
-	_, err = client.UpdateTrackingPlan(planId, updatedPlan)
+	_, err = client.UpdateTrackingPlan(c, planId, updatedPlan)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiag(err, "cannot update tracking plan %q", planId)
 	}
 	return resourceSegmentTrackingPlanRead(c, r, meta)
 }
 
-func TrackingPlanNameToId(name string) string {
-	return strings.Split(name, "/")[3]
-}
-
-func getTrackingPlansNames(client *segment.Client) (map[string]string, error) {
-	plans, err := client.ListTrackingPlans()
+func getTrackingPlansNames(c context.Context, client *Client) (map[string]string, error) {
+	plans, err := client.ListTrackingPlans(c)
 	if err != nil {
 		return nil, fmt.Errorf("cannot list tracking plans: %w", err)
 	}
 	names := make(map[string]string)
 	for _, element := range plans.TrackingPlans {
-		id := TrackingPlanNameToId(element.Name)
+		id, err := TrackingPlanNameToId(client.Workspace, element.Name)
+		if err != nil {
+			return nil, err
+		}
 		names[id] = element.Name
 	}
 	return names, nil
@@ -295,3 +317,65 @@ func toTfState(v interface{}) string {
 	}
 	return string(j)
 }
+
+// trackingPlanImportRulesSuffix appended to the import ID (e.g. "rs_123:rules") makes the import
+// bring all non-empty rules of the tracking plan under management of Terraform
+const trackingPlanImportRulesSuffix = ":rules"
+
+func resourceSegmentTrackingPlanImport(c context.Context, r *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client)
+
+	id := r.Id()
+	importRules := strings.HasSuffix(id, trackingPlanImportRulesSuffix)
+	id = strings.TrimSuffix(id, trackingPlanImportRulesSuffix)
+
+	planId, err := ParseTrackingPlanImportId(client.Workspace, id)
+	if err != nil {
+		return nil, err
+	}
+	r.SetId(planId)
+	if err := r.Set("deletion_protection", false); err != nil {
+		return nil, err
+	}
+
+	if importRules {
+		trackingPlan, err := client.GetTrackingPlan(c, planId)
+		if err != nil {
+			return nil, fmt.Errorf("cannot import rules of tracking plan %q: %w", planId, err)
+		}
+		if err := setTrackingPlanRules(r, trackingPlan.Rules); err != nil {
+			return nil, err
+		}
+	}
+
+	return []*schema.ResourceData{r}, nil
+}
+
+// setTrackingPlanRules sets all non-empty rules in state, so that the subsequent reads keep track of them
+func setTrackingPlanRules(r *schema.ResourceData, rules segment.RuleSet) error {
+	if !IsNilOrZeroValue(rules.Global) {
+		if err := r.Set("rules_global", toTfState(rules.Global)); err != nil {
+			return err
+		}
+	}
+	if !IsNilOrZeroValue(rules.Identify) {
+		if err := r.Set("rules_identify", toTfState(rules.Identify)); err != nil {
+			return err
+		}
+	}
+	if !IsNilOrZeroValue(rules.Group) {
+		if err := r.Set("rules_group", toTfState(rules.Group)); err != nil {
+			return err
+		}
+	}
+	if len(rules.Events) > 0 {
+		events := make([]interface{}, 0, len(rules.Events))
+		for _, e := range rules.Events {
+			events = append(events, toTfState(e))
+		}
+		if err := r.Set("rules_events", events); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -4,9 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"strings"
 
-	"github.com/forteilgmbh/segment-config-go/segment"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -26,23 +24,28 @@ func resourceSegmentTrackingPlanSourceConnection() *schema.Resource {
 				ForceNew:    true,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 		CreateContext: resourceSegmentTrackingPlanSourceConnectionCreate,
 		ReadContext:   resourceSegmentTrackingPlanSourceConnectionRead,
 		DeleteContext: resourceSegmentTrackingPlanSourceConnectionDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceSegmentTrackingPlanSourceConnectionImport,
 		},
 	}
 }
 
 func resourceSegmentTrackingPlanSourceConnectionCreate(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*segment.Client)
+	client := meta.(*Client)
 	planId := r.Get("tracking_plan_id").(string)
 	srcSlug := r.Get("source_slug").(string)
 
-	err := client.CreateTrackingPlanSourceConnection(planId, srcSlug)
+	err := client.CreateTrackingPlanSourceConnection(c, planId, srcSlug)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiag(err, "cannot connect source %q to tracking plan %q", srcSlug, planId)
 	}
 	id := createTrackingPlanSourceConnectionId(planId, srcSlug)
 	r.SetId(id)
@@ -51,16 +54,20 @@ func resourceSegmentTrackingPlanSourceConnectionCreate(c context.Context, r *sch
 }
 
 func resourceSegmentTrackingPlanSourceConnectionRead(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*segment.Client)
-	planId, srcSlug := SplitTrackingPlanSourceConnectionId(r.Id())
+	client := meta.(*Client)
+	planId, srcSlug, err := SplitTrackingPlanSourceConnectionId(r.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	ok, err := FindTrackingPlanSourceConnection(client, planId, srcSlug)
+	ok, err := FindTrackingPlanSourceConnection(c, client, planId, srcSlug)
 	if err != nil {
-		if IsNotFoundErr(err) {
+		switch ErrorKindOf(err) {
+		case ErrorKindNotFound:
 			r.SetId("")
 			return nil
-		} else {
-			return diag.FromErr(err)
+		default:
+			return apiErrorDiag(err, "cannot read connection of source %q to tracking plan %q", srcSlug, planId)
 		}
 	}
 	if !ok {
@@ -79,35 +86,45 @@ func resourceSegmentTrackingPlanSourceConnectionRead(c context.Context, r *schem
 }
 
 func resourceSegmentTrackingPlanSourceConnectionDelete(c context.Context, r *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*segment.Client)
-	planId, srcSlug := SplitTrackingPlanSourceConnectionId(r.Id())
-
-	err := client.DeleteTrackingPlanSourceConnection(planId, srcSlug)
+	client := meta.(*Client)
+	planId, srcSlug, err := SplitTrackingPlanSourceConnectionId(r.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	return nil
-}
-
-func createTrackingPlanSourceConnectionId(planId, srcSlug string) string {
-	return fmt.Sprintf("%s|%s", planId, srcSlug)
-}
+	err = client.DeleteTrackingPlanSourceConnection(c, planId, srcSlug)
+	if err != nil && ErrorKindOf(err) != ErrorKindNotFound {
+		return apiErrorDiag(err, "cannot disconnect source %q from tracking plan %q", srcSlug, planId)
+	}
 
-func SplitTrackingPlanSourceConnectionId(id string) (planId, srcName string) {
-	s := strings.SplitN(id, "|", 2)
-	return s[0], s[1]
+	return nil
 }
 
-func FindTrackingPlanSourceConnection(client *segment.Client, planId, srcSlug string) (bool, error) {
-	trackingPlanSourceConnections, err := client.ListTrackingPlanSources(planId)
+func FindTrackingPlanSourceConnection(c context.Context, client *Client, planId, srcSlug string) (bool, error) {
+	trackingPlanSourceConnections, err := client.ListTrackingPlanSources(c, planId)
 	if err != nil {
 		return false, fmt.Errorf("cannot fetch source connections for tracking plan %q: %w", planId, err)
 	}
 	for _, sc := range trackingPlanSourceConnections {
-		if sc.TrackingPlanId == planId && strings.HasSuffix(sc.Source, srcSlug) {
+		slug, err := SourceNameToSlug(client.Workspace, sc.Source)
+		if err != nil {
+			return false, fmt.Errorf("cannot fetch source connections for tracking plan %q: %w", planId, err)
+		}
+		if sc.TrackingPlanId == planId && slug == srcSlug {
 			return true, nil
 		}
 	}
 	return false, nil
 }
+
+func resourceSegmentTrackingPlanSourceConnectionImport(c context.Context, r *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client)
+
+	planId, srcSlug, err := ParseTrackingPlanSourceConnectionImportId(client.Workspace, r.Id())
+	if err != nil {
+		return nil, err
+	}
+	r.SetId(createTrackingPlanSourceConnectionId(planId, srcSlug))
+
+	return []*schema.ResourceData{r}, nil
+}
@@ -1,8 +1,8 @@
 package segment_test
 
 import (
+	"context"
 	"fmt"
-	segmentapi "github.com/forteilgmbh/segment-config-go/segment"
 	"github.com/forteilgmbh/terraform-provider-segment/segment"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -30,6 +30,19 @@ func TestAccSegmentTrackingPlanSourceConnection_basic(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName: "segment_tracking_plan_source_connection.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["segment_tracking_plan_source_connection.test"]
+					if !ok {
+						return "", fmt.Errorf("not found: segment_tracking_plan_source_connection.test")
+					}
+					client := testAccProvider.Meta().(*segment.Client)
+					return fmt.Sprintf("%s|%s", rs.Primary.Attributes["tracking_plan_id"], segment.SourceSlugToName(client.Workspace, srcName)), nil
+				},
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -56,15 +69,18 @@ func TestAccSegmentTrackingPlanSourceConnection_disappears(t *testing.T) {
 }
 
 func testAccCheckSegmentTrackingPlanSourceConnectionDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*segmentapi.Client)
+	client := testAccProvider.Meta().(*segment.Client)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "segment_tracking_plan_source_connection" {
 			continue
 		}
 
-		planId, srcSlug := segment.SplitTrackingPlanSourceConnectionId(rs.Primary.ID)
-		ok, err := segment.FindTrackingPlanSourceConnection(client, planId, srcSlug)
+		planId, srcSlug, err := segment.SplitTrackingPlanSourceConnectionId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		ok, err := segment.FindTrackingPlanSourceConnection(context.Background(), client, planId, srcSlug)
 		if ok {
 			return fmt.Errorf("tracking plan source connection %q still exists", rs.Primary.ID)
 		}
@@ -83,10 +99,13 @@ func testAccCheckTrackingPlanSourceConnectionExists(name string) resource.TestCh
 		if rs.Primary.ID == "" {
 			return fmt.Errorf("tracking plan source connection %q has no ID set", name)
 		}
-		planId, srcSlug := segment.SplitTrackingPlanSourceConnectionId(rs.Primary.ID)
+		planId, srcSlug, err := segment.SplitTrackingPlanSourceConnectionId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
 
-		client := testAccProvider.Meta().(*segmentapi.Client)
-		ok, err := segment.FindTrackingPlanSourceConnection(client, planId, srcSlug)
+		client := testAccProvider.Meta().(*segment.Client)
+		ok, err = segment.FindTrackingPlanSourceConnection(context.Background(), client, planId, srcSlug)
 		if err != nil {
 			return err
 		}
@@ -100,9 +119,12 @@ func testAccCheckTrackingPlanSourceConnectionExists(name string) resource.TestCh
 func testAccCheckTrackingPlanSourceConnectionDisappears(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs := s.RootModule().Resources[name]
-		planId, srcSlug := segment.SplitTrackingPlanSourceConnectionId(rs.Primary.ID)
-		client := testAccProvider.Meta().(*segmentapi.Client)
-		return client.DeleteTrackingPlanSourceConnection(planId, srcSlug)
+		planId, srcSlug, err := segment.SplitTrackingPlanSourceConnectionId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		client := testAccProvider.Meta().(*segment.Client)
+		return client.DeleteTrackingPlanSourceConnection(context.Background(), planId, srcSlug)
 	}
 }
 
@@ -1,6 +1,7 @@
 package segment_test
 
 import (
+	"context"
 	"embed"
 	"encoding/json"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"regexp"
 	"strings"
 	"testing"
 )
@@ -65,6 +67,19 @@ func TestAccSegmentTrackingPlan_authoritative(t *testing.T) {
 				// by Terraform (attribute is set) and what should be left without changes (attribute is null).
 				// As a consequence, apply is required after import.
 			},
+			{
+				// unless the rules are imported explicitly
+				ResourceName: "segment_tracking_plan.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["segment_tracking_plan.test"]
+					if !ok {
+						return "", fmt.Errorf("not found: segment_tracking_plan.test")
+					}
+					return rs.Primary.ID + ":rules", nil
+				},
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -153,15 +168,62 @@ func TestAccSegmentTrackingPlan_disappears(t *testing.T) {
 	})
 }
 
+func TestAccSegmentTrackingPlan_deletionProtection(t *testing.T) {
+	var tpBefore, tpAfter segmentapi.TrackingPlan
+	rName := acctest.RandomWithPrefix("tf-testacc-tp-protected")
+	resourceName := "segment_tracking_plan.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckSegmentTrackingPlanDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSegmentTrackingPlanConfig_deletionProtection(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTrackingPlanExists(resourceName, &tpBefore),
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      testAccSegmentTrackingPlanConfig_deletionProtection(rName, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("deletion protection is enabled"),
+			},
+			// turning it off does not modify the tracking plan
+			{
+				Config: testAccSegmentTrackingPlanConfig_deletionProtection(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTrackingPlanExists(resourceName, &tpAfter),
+					testAccCheckTrackingPlanNotModified(&tpBefore, &tpAfter),
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckTrackingPlanNotModified(before, after *segmentapi.TrackingPlan) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if !before.CreateTime.Equal(after.CreateTime) {
+			return fmt.Errorf("tracking plan %q was recreated", after.Name)
+		}
+		if !before.UpdateTime.Equal(after.UpdateTime) {
+			return fmt.Errorf("tracking plan %q was updated", after.Name)
+		}
+		return nil
+	}
+}
+
 func testAccCheckSegmentTrackingPlanDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*segmentapi.Client)
+	client := testAccProvider.Meta().(*segment.Client)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "segment_tracking_plan" {
 			continue
 		}
 
-		_, err := client.GetTrackingPlan(rs.Primary.ID)
+		_, err := client.GetTrackingPlan(context.Background(), rs.Primary.ID)
 
 		if err == nil {
 			return fmt.Errorf("tracking plan %q still exists", rs.Primary.ID)
@@ -185,9 +247,9 @@ func testAccCheckTrackingPlanExists(name string, tp *segmentapi.TrackingPlan) re
 			return fmt.Errorf("tracking plan %q has no ID set", name)
 		}
 
-		client := testAccProvider.Meta().(*segmentapi.Client)
+		client := testAccProvider.Meta().(*segment.Client)
 
-		resp, err := client.GetTrackingPlan(rs.Primary.ID)
+		resp, err := client.GetTrackingPlan(context.Background(), rs.Primary.ID)
 		if err != nil {
 			return err
 		}
@@ -199,8 +261,12 @@ func testAccCheckTrackingPlanExists(name string, tp *segmentapi.TrackingPlan) re
 
 func testAccCheckTrackingPlanDisappears(tp *segmentapi.TrackingPlan) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*segmentapi.Client)
-		return client.DeleteTrackingPlan(segment.TrackingPlanNameToId(tp.Name))
+		client := testAccProvider.Meta().(*segment.Client)
+		planId, err := segment.TrackingPlanNameToId(client.Workspace, tp.Name)
+		if err != nil {
+			return err
+		}
+		return client.DeleteTrackingPlan(context.Background(), planId)
 	}
 }
 
@@ -253,6 +319,15 @@ EOF
 `, rName, ruleStringFromFile(rulesFile))
 }
 
+func testAccSegmentTrackingPlanConfig_deletionProtection(rName string, deletionProtection bool) string {
+	return fmt.Sprintf(`
+resource "segment_tracking_plan" "test" {
+  display_name        = %q
+  deletion_protection = %t
+}
+`, rName, deletionProtection)
+}
+
 func testAccSegmentTrackingPlanConfig_identify_events(rName, identifyFile string, eventsFiles []string) string {
 	events := make([]string, 0, len(eventsFiles))
 	for _, f := range eventsFiles {
@@ -281,9 +356,9 @@ func testAccUpdateTrackingPlan(name string, tp *segmentapi.TrackingPlan, eventsF
 			events = append(events, eventFromFile(f))
 		}
 
-		client := testAccProvider.Meta().(*segmentapi.Client)
+		client := testAccProvider.Meta().(*segment.Client)
 		tp.Rules.Events = events
-		_, err := client.UpdateTrackingPlan(rs.Primary.ID, *tp)
+		_, err := client.UpdateTrackingPlan(context.Background(), rs.Primary.ID, *tp)
 		if err != nil {
 			return fmt.Errorf("error updating tracking plan %q: %w", tp.Name, err)
 		}